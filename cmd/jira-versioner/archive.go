@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/psmarcin/jira-versioner/pkg/jira"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const day = 24 * time.Hour
+
+func newArchiveCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "archive",
+		Short: "Archive old released versions",
+		Long: `Archive released versions older than given number of days or beyond given number of the latest releases.
+When both are set, version has to match both of them.`,
+		Example: "jira-versioner archive -e jira@example.com -k pa$$wor0 -p 10003 -u https://example.atlassian.net --keep-last 20",
+		Args:    cobra.NoArgs,
+		PreRunE: requireFlags(jiraCredentialFlags...),
+		Run:     archiveFunc,
+	}
+	c.Flags().Int("older-than-days", 0, "Archive versions released more than given number of days ago, 0 means any release date")
+	c.Flags().Int("keep-last", 0, "Keep given number of the latest released versions, 0 means none is kept")
+	c.Flags().String("name-pattern", "", "Archive only versions with name matching regular expression, example: ^v1\\.")
+	c.Flags().Bool("json", false, "Print summary as JSON")
+
+	return c
+}
+
+func archiveFunc(c *cobra.Command, _ []string) {
+	asJSON := c.Flag("json").Value.String() == "true"
+	log := zap.NewExample().Sugar()
+	if asJSON {
+		log = newStderrLogger()
+	}
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	policy, err := getArchivePolicy(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing archive params %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+	ctx, cancel, err := commandContext(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing timeout param %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+	defer cancel()
+
+	j, err := connectJira(ctx, c, log, nil)
+	if err != nil {
+		logJiraError(log, "error while connecting to jira server", err)
+		defer exitWithError() //nolint
+		return
+	}
+
+	report, err := j.ArchiveVersions(ctx, policy)
+	if err != nil {
+		logJiraError(log, "error while archiving versions", err)
+		defer exitWithError() //nolint
+		return
+	}
+
+	if asJSON {
+		out, marshalErr := json.MarshalIndent(report, "", "  ")
+		if marshalErr != nil {
+			log.Errorf("[JIRA-VERSIONER] error while printing summary %+v", marshalErr)
+			defer exitWithError() //nolint
+			return
+		}
+		fmt.Println(string(out))
+	} else {
+		logArchiveReport(log, report)
+	}
+
+	if report.HasFailures() {
+		defer exitWithError() //nolint
+		return
+	}
+}
+
+// getArchivePolicy builds archive policy from command flags
+func getArchivePolicy(c *cobra.Command) (jira.ArchivePolicy, error) {
+	var policy jira.ArchivePolicy
+
+	days, err := c.Flags().GetInt("older-than-days")
+	if err != nil {
+		return policy, err
+	}
+	policy.OlderThan = time.Duration(days) * day
+	policy.KeepLast, err = c.Flags().GetInt("keep-last")
+	if err != nil {
+		return policy, err
+	}
+	if pattern := c.Flag("name-pattern").Value.String(); pattern != "" {
+		policy.NamePattern, err = regexp.Compile(pattern)
+		if err != nil {
+			return policy, err
+		}
+	}
+
+	return policy, nil
+}
+
+// logArchiveReport prints archived versions
+func logArchiveReport(log *zap.SugaredLogger, report jira.ArchiveReport) {
+	prefix := ""
+	if report.DryRun {
+		prefix = "(dry run) "
+	}
+	log.Infof("[JIRA-VERSIONER] %sarchiving %d of %d released versions", prefix, len(report.Versions), report.Matched)
+	for _, v := range report.Versions {
+		if v.Status == jira.ArchiveStatusFailed {
+			log.Warnf("[JIRA-VERSIONER] %s failed: %s", v.Name, v.Error)
+			continue
+		}
+		log.Infof("[JIRA-VERSIONER] %s %s (released %s)", v.Status, v.Name, v.ReleaseDate)
+	}
+}
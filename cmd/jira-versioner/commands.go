@@ -0,0 +1,185 @@
+package main
+
+import "github.com/spf13/cobra"
+
+func newCreateVersionCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "create-version",
+		Short:   "Create Jira version for the tag, existing version is reused",
+		Example: "jira-versioner create-version -e jira@example.com -k pa$$wor0 -p 10003 -t v1.1.0 -u https://example.atlassian.net",
+		Args:    cobra.NoArgs,
+		PreRunE: requireFlags(append([]string{"tag"}, jiraCredentialFlags...)...),
+		Run:     createVersionFunc,
+	}
+
+	return c
+}
+
+func newLinkCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "link",
+		Short:   "Link tasks found in commits since previous tag to existing Jira version",
+		Example: "jira-versioner link -e jira@example.com -k pa$$wor0 -p 10003 -t v1.1.0 -u https://example.atlassian.net",
+		Args:    cobra.NoArgs,
+		PreRunE: requireFlags(append([]string{"tag"}, jiraCredentialFlags...)...),
+		Run:     linkFunc,
+	}
+	addIssueFlags(c)
+	addLinkFlags(c)
+
+	return c
+}
+
+func newReleaseCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "release",
+		Short: "Mark Jira version as released, transition and comment its tasks found in commits since previous tag",
+		Example: "jira-versioner release -e jira@example.com -k pa$$wor0 -p 10003 -t v1.1.0 -u https://example.atlassian.net " +
+			"--transition Released --comment",
+		Args:    cobra.NoArgs,
+		PreRunE: requireFlags(append([]string{"tag"}, jiraCredentialFlags...)...),
+		Run:     releaseFunc,
+	}
+	addIssueFlags(c)
+	addReleaseFlags(c)
+
+	return c
+}
+
+func createVersionFunc(c *cobra.Command, _ []string) {
+	log := commandLogger(c)
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	ctx, cancel, err := commandContext(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing timeout param %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+	defer cancel()
+
+	s, err := newSession(ctx, c, log, getJiraConnectionConfig)
+	if err != nil {
+		defer exitWithError() //nolint
+		return
+	}
+	if isDryRun(c) {
+		err = s.planVersion(ctx, c)
+		if err != nil {
+			defer exitWithError() //nolint
+		}
+		return
+	}
+
+	err = s.createVersion(ctx, c)
+	if err != nil {
+		defer exitWithError() //nolint
+		return
+	}
+	log.Infof("[JIRA-VERSIONER] version %s (%s) ready ✅", s.jira.Version.Name, s.jira.Version.ID)
+}
+
+func linkFunc(c *cobra.Command, _ []string) {
+	log := commandLogger(c)
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	ctx, cancel, err := commandContext(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing timeout param %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+	defer cancel()
+
+	s, err := newSession(ctx, c, log, getJiraConfig)
+	if err != nil {
+		defer exitWithError() //nolint
+		return
+	}
+	if isDryRun(c) {
+		err = s.planLink(ctx, c, false, false)
+		if err != nil {
+			defer exitWithError() //nolint
+		}
+		return
+	}
+
+	err = s.useVersion(ctx, c)
+	if err != nil {
+		defer exitWithError() //nolint
+		return
+	}
+
+	report, err := s.link(ctx, c)
+	if err != nil {
+		defer exitWithError() //nolint
+		return
+	}
+	if s.jira.HasLabelEdits() {
+		report = s.jira.LabelLinkedTasks(ctx, report)
+	}
+
+	err = s.finish(ctx, report)
+	if err != nil {
+		defer exitWithError() //nolint
+		return
+	}
+}
+
+func releaseFunc(c *cobra.Command, _ []string) {
+	log := commandLogger(c)
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	ctx, cancel, err := commandContext(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing timeout param %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+	defer cancel()
+
+	s, err := newSession(ctx, c, log, getJiraConfig)
+	if err != nil {
+		defer exitWithError() //nolint
+		return
+	}
+	if isDryRun(c) {
+		err = s.planRelease(ctx, c)
+		if err != nil {
+			defer exitWithError() //nolint
+		}
+		return
+	}
+
+	err = s.useVersion(ctx, c)
+	if err != nil {
+		defer exitWithError() //nolint
+		return
+	}
+	err = s.jira.ReleaseVersion(ctx)
+	if err != nil {
+		logJiraError(log, "error while releasing version", err)
+		defer exitWithError() //nolint
+		return
+	}
+
+	report, err := s.jira.LinkedTasksReport(ctx, s.keys())
+	if err != nil {
+		logJiraError(log, "error while getting tasks linked to version", err)
+		defer exitWithError() //nolint
+		return
+	}
+	report = s.release(ctx, c, report)
+
+	err = s.finish(ctx, report)
+	if err != nil {
+		defer exitWithError() //nolint
+		return
+	}
+}
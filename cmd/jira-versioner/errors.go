@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/psmarcin/jira-versioner/pkg/jira"
+	"go.uber.org/zap"
+)
+
+// logJiraError logs Jira error with its details and hint what user can do about it
+func logJiraError(log *zap.SugaredLogger, message string, err error) {
+	log.Errorf("[VERSION] %s: %s", message, err)
+	log.Debugf("[VERSION] error details %+v", err)
+	if hint := explainJiraError(err); hint != "" {
+		log.Errorf("[VERSION] %s", hint)
+	}
+}
+
+// explainJiraError turns Jira error into hint what user can do about it, empty if there is no hint
+func explainJiraError(err error) string {
+	var transportErr *jira.TransportError
+
+	switch {
+	case errors.Is(err, jira.ErrNotifyUsersPermission):
+		return "run with --notify-users=true or grant Administer Projects permission to the account"
+	case errors.As(err, &transportErr):
+		return "can't reach Jira, check --jira-base-url and network connection"
+	case errors.Is(err, jira.ErrUnauthorized):
+		return "Jira rejected credentials, check --jira-email and --jira-token"
+	case errors.Is(err, jira.ErrForbidden):
+		return "account has no permission, make sure it can browse and edit issues and manage versions in the project"
+	case errors.Is(err, jira.ErrNotFound):
+		return "resource not found, check --jira-project (it has to be project ID) or the account can't see it"
+	case errors.Is(err, jira.ErrVersionConflict):
+		return "version with this name already exists in the project, pick another name"
+	case errors.Is(err, jira.ErrRateLimited):
+		return "Jira rate limit exceeded, increase --retry-max-wait or --jira-retry-times"
+	default:
+		return ""
+	}
+}
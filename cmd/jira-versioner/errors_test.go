@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gojira "github.com/andygrunwald/go-jira"
+	"github.com/psmarcin/jira-versioner/pkg/jira"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExplainJiraError_NotifyUsersPermission(t *testing.T) {
+	err := fmt.Errorf("can't connect: %w", jira.ErrNotifyUsersPermission)
+
+	assert.Equal(t, "run with --notify-users=true or grant Administer Projects permission to the account", explainJiraError(err))
+}
+
+func TestExplainJiraError_RetriesExhausted(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		target error
+		hint   string
+	}{
+		{
+			name:   "should explain rate limit",
+			status: http.StatusTooManyRequests,
+			target: jira.ErrRateLimited,
+			hint:   "Jira rate limit exceeded, increase --retry-max-wait or --jira-retry-times",
+		},
+		{
+			name:   "should explain rejected credentials",
+			status: http.StatusUnauthorized,
+			target: jira.ErrUnauthorized,
+			hint:   "Jira rejected credentials, check --jira-email and --jira-token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/rest/api/2/project/10000", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprint(w, `{"id": "10000", "key": "JR"}`)
+			})
+			mux.HandleFunc("/rest/api/2/issue/JR-1", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.status)
+				_, _ = fmt.Fprint(w, `{"errorMessages": ["request rejected"], "errors": {}}`)
+			})
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+
+			j, err := jira.New(context.Background(), &jira.Config{
+				ProjectID:      "10000",
+				BaseURL:        server.URL,
+				Log:            zap.NewNop().Sugar(),
+				HTTPMaxRetries: 2,
+				RetryMaxWait:   time.Millisecond,
+			})
+			require.NoError(t, err)
+			j.Version = &gojira.Version{ID: "10100", Name: "v1.0.0"}
+
+			err = j.SetIssueVersion(context.Background(), "JR-1")
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.target), "expected %v, got %v", tt.target, err)
+			assert.Equal(t, tt.hint, explainJiraError(err))
+		})
+	}
+}
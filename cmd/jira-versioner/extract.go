@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/psmarcin/jira-versioner/pkg/git"
+	"github.com/spf13/cobra"
+)
+
+// shortHashLength is length of commit hash printed in text output
+const shortHashLength = 7
+
+// extractedTask is task printed by extract command
+type extractedTask struct {
+	Key     string            `json:"key"`
+	Types   []string          `json:"types"`
+	Commits []extractedCommit `json:"commits"`
+}
+
+// extractedCommit is commit referencing extracted task
+type extractedCommit struct {
+	Hash        string `json:"hash"`
+	AuthorEmail string `json:"authorEmail"`
+	Subject     string `json:"subject"`
+}
+
+func newExtractCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "extract",
+		Short: "Print tasks found in commits since previous tag, doesn't need Jira credentials",
+		Long: `Print tasks found in commits since previous tag together with commits referencing them.
+It only reads git history, so it doesn't need Jira credentials.`,
+		Example: "jira-versioner extract -t v1.1.0 --json",
+		Args:    cobra.NoArgs,
+		PreRunE: requireFlags("tag"),
+		Run:     extractFunc,
+	}
+	c.Flags().Bool("json", false, "Print tasks as JSON")
+
+	return c
+}
+
+func extractFunc(c *cobra.Command, _ []string) {
+	// stdout is left for tasks only
+	log := newStderrLogger()
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	tag := c.Flag("tag").Value.String()
+	asJSON := c.Flag("json").Value.String() == "true"
+	gitSettings, err := getGitSettings(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing git params %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+
+	g := git.New(c.Flag("dir").Value.String(), gitSettings, log)
+	tasks, err := g.GetTaskDetails(tag)
+	if err != nil {
+		log.Errorf("[GIT] error while getting tasks since latest commit %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+
+	if asJSON {
+		err = printTasksJSON(os.Stdout, tasks)
+	} else {
+		err = printTasks(os.Stdout, tasks)
+	}
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while printing tasks %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+}
+
+// printTasks prints each task key with its types followed by indented commits
+func printTasks(w io.Writer, tasks []git.Task) error {
+	for _, task := range tasks {
+		line := task.Key
+		if len(task.Types) > 0 {
+			line += " (" + strings.Join(task.Types, ", ") + ")"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		for _, commit := range task.Commits {
+			hash := commit.Hash
+			if len(hash) > shortHashLength {
+				hash = hash[:shortHashLength]
+			}
+			if _, err := fmt.Fprintf(w, "  %s %s\n", hash, commit.Subject); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// printTasksJSON prints tasks with their commits as JSON array
+func printTasksJSON(w io.Writer, tasks []git.Task) error {
+	extracted := make([]extractedTask, 0, len(tasks))
+	for _, task := range tasks {
+		t := extractedTask{Key: task.Key, Types: task.Types, Commits: make([]extractedCommit, 0, len(task.Commits))}
+		if t.Types == nil {
+			t.Types = []string{}
+		}
+		for _, commit := range task.Commits {
+			t.Commits = append(t.Commits, extractedCommit{
+				Hash:        commit.Hash,
+				AuthorEmail: commit.AuthorEmail,
+				Subject:     commit.Subject,
+			})
+		}
+		extracted = append(extracted, t)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(extracted)
+}
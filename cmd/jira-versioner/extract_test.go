@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/psmarcin/jira-versioner/pkg/cmd"
+	"github.com/psmarcin/jira-versioner/pkg/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintTasks(t *testing.T) {
+	feat := cmd.Commit{Hash: "0123456789abcdef", AuthorEmail: "dev@example.com", Subject: "feat(api): JR-1 add endpoint"}
+	fix := cmd.Commit{Hash: "abc12", AuthorEmail: "dev@example.com", Subject: "fix: JR-1 fix endpoint"}
+	plain := cmd.Commit{Hash: "fedcba9876543210", AuthorEmail: "bot@example.com", Subject: "JR-2 not conventional"}
+
+	tests := []struct {
+		name  string
+		tasks []git.Task
+		want  string
+	}{
+		{
+			name:  "should print task types and short hashes",
+			tasks: []git.Task{{Key: "JR-1", Types: []string{"feat", "fix"}, Commits: []cmd.Commit{feat, fix}}},
+			want:  "JR-1 (feat, fix)\n  0123456 feat(api): JR-1 add endpoint\n  abc12 fix: JR-1 fix endpoint\n",
+		},
+		{
+			name:  "should print task without types",
+			tasks: []git.Task{{Key: "JR-2", Commits: []cmd.Commit{plain}}},
+			want:  "JR-2\n  fedcba9 JR-2 not conventional\n",
+		},
+		{
+			name: "should print nothing without tasks",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			err := printTasks(&out, tt.tasks)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestPrintTasksJSON(t *testing.T) {
+	plain := cmd.Commit{Hash: "fedcba9876543210", AuthorEmail: "bot@example.com", Subject: "JR-2 not conventional"}
+
+	tests := []struct {
+		name  string
+		tasks []git.Task
+		want  string
+	}{
+		{
+			name:  "should print full hash, author and subject",
+			tasks: []git.Task{{Key: "JR-1", Types: []string{"feat"}, Commits: []cmd.Commit{plain}}},
+			want: `[{"key": "JR-1", "types": ["feat"], "commits": [
+				{"hash": "fedcba9876543210", "authorEmail": "bot@example.com", "subject": "JR-2 not conventional"}
+			]}]`,
+		},
+		{
+			name:  "should print empty types instead of null",
+			tasks: []git.Task{{Key: "JR-2"}},
+			want:  `[{"key": "JR-2", "types": [], "commits": []}]`,
+		},
+		{
+			name: "should print empty array without tasks",
+			want: `[]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			err := printTasksJSON(&out, tt.tasks)
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, out.String())
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/psmarcin/jira-versioner/pkg/jira"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// addJiraConnectionFlags registers flags needed to connect to Jira, they are shared by all commands
+func addJiraConnectionFlags(c *cobra.Command) {
+	c.PersistentFlags().StringP("jira-email", "e", "", "Jira email")
+	c.PersistentFlags().StringP("jira-token", "k", "", "Jira token/key/password")
+	c.PersistentFlags().StringP("jira-project", "p", "", "Jira project, it has to be ID, example: 10003")
+	c.PersistentFlags().StringP("jira-base-url", "u", "", "Jira service base url, example: https://example.atlassian.net")
+	c.PersistentFlags().IntP("jira-retry-times", "r", 3, "Jira retry times for HTTP requests if failed")
+	c.PersistentFlags().Bool("dry-run", false, "Enable dry run mode")
+	c.PersistentFlags().Duration("timeout", 0, "Timeout of the whole run, 0 means no timeout")
+	c.PersistentFlags().Duration("http-timeout", defaultHTTPTimeout, "Timeout of single Jira HTTP request, 0 means no timeout")
+	c.PersistentFlags().Duration(
+		"retry-max-wait",
+		jira.DefaultRetryMaxWait,
+		"Maximum wait between retries, also when Jira rate limiting asks for longer",
+	)
+}
+
+// addIssueFlags registers flags controlling how tasks are updated in Jira
+func addIssueFlags(c *cobra.Command) {
+	c.Flags().String(
+		"jira-field",
+		jira.FieldFixVersions,
+		"Version field tasks are linked with: fixVersions, versions (Affects Version) or customfield_XXXXX",
+	)
+	c.Flags().Bool("notify-users", true, "Send Jira notification emails on issue updates, disabling requires admin permission")
+}
+
+// addLinkFlags registers flags controlling how tasks are linked to version
+func addLinkFlags(c *cobra.Command) {
+	c.Flags().String(
+		"fix-version-mode",
+		string(jira.FixVersionModeAdd),
+		"How version is put into fixVersions: add, replace or replace-unreleased (keeps released versions)",
+	)
+	c.Flags().StringSlice("add-label", nil, "Add label to linked tasks, can be repeated")
+	c.Flags().StringSlice("remove-label", nil, "Remove label from linked tasks, can be repeated")
+	c.Flags().Bool("sync", false, "Remove version from issues which are no longer referenced by commits in the range")
+}
+
+// addReleaseFlags registers flags controlling what happens with linked tasks on release
+func addReleaseFlags(c *cobra.Command) {
+	c.Flags().String("transition", "", "Workflow transition applied to linked tasks, example: Released")
+	c.Flags().StringSlice("transition-from", nil, "Only tasks in given statuses are transitioned, can be repeated, default any status")
+	c.Flags().Bool("comment", false, "Add comment with release information to linked tasks")
+	c.Flags().String("comment-template", "", "Go template of comment, fields: .Version, .Tag, .Issue, .Commits, .BuildURL")
+	c.Flags().String("build-url", os.Getenv("BUILD_URL"), "CI build URL put into comment, default BUILD_URL env variable")
+}
+
+// getJiraConnectionConfig builds Jira config from connection flags only
+func getJiraConnectionConfig(c *cobra.Command, log *zap.SugaredLogger) (jira.Config, error) {
+	var err error
+	config := jira.Config{
+		Username:  c.Flag("jira-email").Value.String(),
+		Token:     c.Flag("jira-token").Value.String(),
+		ProjectID: c.Flag("jira-project").Value.String(),
+		BaseURL:   c.Flag("jira-base-url").Value.String(),
+		Log:       log,
+		DryRun:    c.Flag("dry-run").Value.String() == "true",
+	}
+
+	config.HTTPMaxRetries, err = c.Flags().GetInt("jira-retry-times")
+	if err != nil {
+		return config, err
+	}
+	config.HTTPTimeout, err = c.Flags().GetDuration("http-timeout")
+	if err != nil {
+		return config, err
+	}
+	config.RetryMaxWait, err = c.Flags().GetDuration("retry-max-wait")
+	if err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// getJiraConfig builds Jira config from issue flags together with link and release flags the command has
+func getJiraConfig(c *cobra.Command, log *zap.SugaredLogger) (jira.Config, error) {
+	config, err := getJiraConnectionConfig(c, log)
+	if err != nil {
+		return config, err
+	}
+	config.Field = c.Flag("jira-field").Value.String()
+	config.DisableNotifications = c.Flag("notify-users").Value.String() != "true"
+
+	if c.Flags().Lookup("fix-version-mode") != nil {
+		// only linking edits the field, release just searches by it
+		config.ValidateField = true
+		config.FixVersionMode, err = jira.ParseFixVersionMode(c.Flag("fix-version-mode").Value.String())
+		if err != nil {
+			return config, err
+		}
+		config.AddLabels, err = c.Flags().GetStringSlice("add-label")
+		if err != nil {
+			return config, err
+		}
+		config.RemoveLabels, err = c.Flags().GetStringSlice("remove-label")
+		if err != nil {
+			return config, err
+		}
+	}
+	if c.Flags().Lookup("transition") != nil {
+		config.Transition = c.Flag("transition").Value.String()
+		config.TransitionFrom, err = c.Flags().GetStringSlice("transition-from")
+		if err != nil {
+			return config, err
+		}
+	}
+
+	return config, nil
+}
+
+// commandContext creates context of the command limited by timeout flag
+func commandContext(c *cobra.Command) (context.Context, context.CancelFunc, error) {
+	timeout, err := c.Flags().GetDuration("timeout")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := newContext(timeout)
+	return ctx, cancel, nil
+}
+
+// connectJira connects to Jira using connection flags, configure can adjust config before connecting
+func connectJira(ctx context.Context, c *cobra.Command, log *zap.SugaredLogger, configure func(*jira.Config)) (jira.Jira, error) {
+	config, err := getJiraConnectionConfig(c, log)
+	if err != nil {
+		return jira.Jira{}, err
+	}
+	if configure != nil {
+		configure(&config)
+	}
+
+	return jira.New(ctx, &config)
+}
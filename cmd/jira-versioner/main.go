@@ -1,25 +1,37 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
-	"strconv"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/psmarcin/jira-versioner/pkg/git"
 	"github.com/psmarcin/jira-versioner/pkg/jira"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
+const defaultHTTPTimeout = 30 * time.Second
+
 func main() {
 	rootCmd := &cobra.Command{
 		Use:   "jira-versioner",
 		Short: "A simple version setter for Jira tasks since last version",
 		Long: `A solution for automatically create version, 
 link all issues from commits to newly created version. 
-All automatically.`,
-		Run: rootFunc,
+All automatically.
+
+Without command it creates version, links tasks to it, transitions and comments them,
+each of these steps can be run separately with create-version, link and release commands.`,
+		PreRunE: requireFlags(append([]string{"tag"}, jiraCredentialFlags...)...),
+		Run:     rootFunc,
 	}
 	// get current directory path
 	ex, err := os.Executable()
@@ -28,41 +40,28 @@ All automatically.`,
 	}
 	pwd := filepath.Dir(ex)
 
-	rootCmd.Flags().StringP("jira-version", "v", "", "Version name for Jira")
-	rootCmd.Flags().StringP("tag", "t", "", "Existing git tag")
-	rootCmd.Flags().StringP("jira-email", "e", "", "Jira email")
-	rootCmd.Flags().StringP("jira-token", "k", "", "Jira token/key/password")
-	rootCmd.Flags().StringP("jira-project", "p", "", "Jira project, it has to be ID, example: 10003")
-	rootCmd.Flags().StringP("jira-base-url", "u", "", "Jira service base url, example: https://example.atlassian.net")
-	rootCmd.Flags().IntP("jira-retry-times", "r", 3, "Jira retry times for HTTP requests if failed")
-	rootCmd.Flags().StringP("dir", "d", pwd, "Absolute directory path to git repository")
-	_ = rootCmd.Flags().BoolP("dry-run", "", false, "Enable dry run mode")
-
-	err = rootCmd.MarkFlagRequired("tag")
-	if err != nil {
-		fmt.Printf("err: %+v", err)
-		os.Exit(1)
-	}
-	err = rootCmd.MarkFlagRequired("jira-email")
-	if err != nil {
-		fmt.Printf("err: %+v", err)
-		os.Exit(1)
-	}
-	err = rootCmd.MarkFlagRequired("jira-token")
-	if err != nil {
-		fmt.Printf("err: %+v", err)
-		os.Exit(1)
-	}
-	err = rootCmd.MarkFlagRequired("jira-project")
-	if err != nil {
-		fmt.Printf("err: %+v", err)
-		os.Exit(1)
-	}
-	err = rootCmd.MarkFlagRequired("jira-base-url")
-	if err != nil {
-		fmt.Printf("err: %+v", err)
-		os.Exit(1)
-	}
+	rootCmd.PersistentFlags().StringP("jira-version", "v", "", "Version name for Jira, overrides version-template")
+	rootCmd.PersistentFlags().String(
+		"version-template",
+		jira.DefaultVersionTemplate,
+		"Go template of version name, fields: .Tag, .Major, .Minor, .Patch, .Prerelease, .Build, .Project, .Date, .Branch",
+	)
+	rootCmd.PersistentFlags().StringP("tag", "t", "", "Existing git tag")
+	rootCmd.PersistentFlags().StringP("dir", "d", pwd, "Absolute directory path to git repository")
+	addJiraConnectionFlags(rootCmd)
+	addGitSettingsFlags(rootCmd)
+	addIssueFlags(rootCmd)
+	addLinkFlags(rootCmd)
+	addReleaseFlags(rootCmd)
+	rootCmd.AddCommand(
+		newCreateVersionCommand(),
+		newLinkCommand(),
+		newReleaseCommand(),
+		newExtractCommand(),
+		newVerifyCommand(),
+		newArchiveCommand(),
+		newVersionCommand(),
+	)
 
 	rootCmd.Example = "jira-versioner -e jira@example.com -k pa$$wor0 -p 10003 -t v1.1.0 -u https://example.atlassian.net"
 
@@ -72,88 +71,131 @@ All automatically.`,
 	}
 }
 
+// rootFunc runs all steps: creates version, links tasks to it, transitions and comments them
 func rootFunc(c *cobra.Command, _ []string) {
-	log := zap.NewExample().Sugar()
-	dryRun := false
+	log := commandLogger(c)
 	defer func() {
 		_ = log.Sync()
 	}()
 
-	tag := c.Flag("tag").Value.String()
-
-	version := c.Flag("jira-version").Value.String()
-	if version == "" {
-		version = tag
+	ctx, cancel, err := commandContext(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing timeout param %+v", err)
+		defer exitWithError() //nolint
+		return
 	}
+	defer cancel()
 
-	jiraEmail := c.Flag("jira-email").Value.String()
-	jiraToken := c.Flag("jira-token").Value.String()
-	jiraProject := c.Flag("jira-project").Value.String()
-	jiraBaseURL := c.Flag("jira-base-url").Value.String()
-	jiraRetryTimes := c.Flag("jira-retry-times").Value.String()
-	retryTimes, err := strconv.Atoi(jiraRetryTimes)
+	s, err := newSession(ctx, c, log, getJiraConfig)
 	if err != nil {
-		log.Errorf("[JIRA-VERSIONER] error while parsing jira-retry-times param %+v", err)
 		defer exitWithError() //nolint
 		return
 	}
-	dryRunRaw := c.Flag("dry-run").Value.String()
-	if dryRunRaw == "true" {
-		dryRun = true
-	}
-	gitDir := c.Flag("dir").Value.String()
-
-	log.Debugf(
-		"[JIRA-VERSIONER] starting with parameters: %+v",
-		map[string]interface{}{
-			"jiraEmail":      jiraEmail,
-			"jiraToken":      jiraToken,
-			"jiraProject":    jiraProject,
-			"jiraBaseURL":    jiraBaseURL,
-			"jiraRetryTimes": retryTimes,
-			"gitDir":         gitDir,
-			"tag":            tag,
-			"version":        version,
-			"dryRun":         dryRun,
-		},
-	)
-	log.Infof("[JIRA-VERSIONER] git directory: %s", gitDir)
-
-	g := git.New(gitDir, log)
+	if isDryRun(c) {
+		err = s.planLink(ctx, c, true, true)
+		if err != nil {
+			defer exitWithError() //nolint
+		}
+		return
+	}
 
-	tasks, err := g.GetTasks(tag)
+	err = s.createVersion(ctx, c)
 	if err != nil {
-		log.Errorf("[GIT] error while getting tasks since latest commit %+v", err)
 		defer exitWithError() //nolint
 		return
 	}
 
-	var jiraConfig = jira.Config{
-		Username:       jiraEmail,
-		Token:          jiraToken,
-		ProjectID:      jiraProject,
-		BaseURL:        jiraBaseURL,
-		Log:            log,
-		DryRun:         dryRun,
-		HTTPMaxRetries: retryTimes,
-	}
-	j, err := jira.New(&jiraConfig)
+	report, err := s.link(ctx, c)
 	if err != nil {
-		log.Errorf("[VERSION] error while connecting to jira server %+v", err)
 		defer exitWithError() //nolint
 		return
 	}
+	if s.jira.HasLabelEdits() {
+		report = s.jira.LabelLinkedTasks(ctx, report)
+	}
+	report = s.release(ctx, c, report)
 
-	_, err = j.CreateVersion(version)
+	err = s.finish(ctx, report)
 	if err != nil {
-		log.Errorf("[VERSION] error while creating version %+v", err)
 		defer exitWithError() //nolint
 		return
 	}
+}
+
+// newContext creates context canceled on SIGINT, SIGTERM or after timeout, zero timeout means no limit
+func newContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	if timeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// commentData builds comment template data for each task
+func commentData(tasks []git.Task, tag, buildURL string) map[string]jira.CommentData {
+	data := make(map[string]jira.CommentData, len(tasks))
+	for _, task := range tasks {
+		hashes := make([]string, 0, len(task.Commits))
+		for _, commit := range task.Commits {
+			hashes = append(hashes, commit.Hash)
+		}
+		data[task.Key] = jira.CommentData{
+			Tag:      tag,
+			Issue:    task.Key,
+			Commits:  hashes,
+			BuildURL: buildURL,
+		}
+	}
+
+	return data
+}
+
+// describeTasks builds version description with task keys grouped by Conventional Commits type
+func describeTasks(tasks []git.Task) string {
+	const otherType = "other"
+	byType := make(map[string][]string)
+	for _, task := range tasks {
+		types := task.Types
+		if len(types) == 0 {
+			types = []string{otherType}
+		}
+		for _, t := range types {
+			byType[t] = append(byType[t], task.Key)
+		}
+	}
+
+	types := make([]string, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	lines := make([]string, 0, len(types))
+	for _, t := range types {
+		lines = append(lines, fmt.Sprintf("%s: %s", t, strings.Join(byType[t], ", ")))
+	}
 
-	j.LinkTasksToVersion(tasks)
+	return strings.Join(lines, "\n")
+}
+
+// newStderrLogger creates logger like zap.NewExample writing to stderr, so stdout is left for command output
+func newStderrLogger() *zap.SugaredLogger {
+	encoderConfig := zapcore.EncoderConfig{
+		MessageKey:     "msg",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.Lock(os.Stderr), zap.DebugLevel)
 
-	log.Infof("[JIRA-VERSIONER] done ✅")
+	return zap.New(core).Sugar()
 }
 
 func exitWithError() {
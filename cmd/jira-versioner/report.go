@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/psmarcin/jira-versioner/pkg/jira"
+	"go.uber.org/zap"
+)
+
+// logReport prints summary of the run grouped by task status
+func logReport(log *zap.SugaredLogger, report jira.Report) {
+	statuses := []jira.IssueStatus{
+		jira.IssueStatusLinked,
+		jira.IssueStatusAlreadyLinked,
+		jira.IssueStatusRemoved,
+		jira.IssueStatusFailed,
+	}
+
+	log.Infof("[JIRA-VERSIONER] summary for version %s:", report.Version)
+	for _, status := range statuses {
+		keys := report.Keys(status)
+		log.Infof("[JIRA-VERSIONER] %s: %d %s", status, len(keys), strings.Join(keys, ", "))
+	}
+	for _, issue := range report.Issues {
+		if issue.Status != jira.IssueStatusFailed {
+			continue
+		}
+		if hint := explainJiraError(issue.Err); hint != "" {
+			log.Warnf("[JIRA-VERSIONER] %s failed: %s (%s)", issue.Key, issue.Err, hint)
+		} else {
+			log.Warnf("[JIRA-VERSIONER] %s failed: %s", issue.Key, issue.Err)
+		}
+	}
+	log.Infof("[JIRA-VERSIONER] retries: %d, waited %s", report.Retries.Attempts, report.Retries.TotalWait)
+
+	transitions := []jira.TransitionStatus{
+		jira.TransitionStatusDone,
+		jira.TransitionStatusSkipped,
+		jira.TransitionStatusUnavailable,
+		jira.TransitionStatusFailed,
+	}
+	for _, status := range transitions {
+		keys := report.Keys(status)
+		if len(keys) > 0 {
+			log.Infof("[JIRA-VERSIONER] transition %s: %d %s", status, len(keys), strings.Join(keys, ", "))
+		}
+	}
+
+	comments := []jira.CommentStatus{
+		jira.CommentStatusDone,
+		jira.CommentStatusAlreadyDone,
+		jira.CommentStatusFailed,
+	}
+	for _, status := range comments {
+		keys := report.Keys(status)
+		if len(keys) > 0 {
+			log.Infof("[JIRA-VERSIONER] comment %s: %d %s", status, len(keys), strings.Join(keys, ", "))
+		}
+	}
+
+	for _, status := range []jira.LabelStatus{jira.LabelStatusDone, jira.LabelStatusFailed} {
+		keys := report.Keys(status)
+		if len(keys) > 0 {
+			log.Infof("[JIRA-VERSIONER] labels %s: %d %s", status, len(keys), strings.Join(keys, ", "))
+		}
+	}
+}
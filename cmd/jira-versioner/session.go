@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/psmarcin/jira-versioner/pkg/git"
+	"github.com/psmarcin/jira-versioner/pkg/jira"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// jiraCredentialFlags are required by every command talking to Jira
+var jiraCredentialFlags = []string{"jira-email", "jira-token", "jira-project", "jira-base-url"}
+
+// configGetter reads Jira config from command flags
+type configGetter func(c *cobra.Command, log *zap.SugaredLogger) (jira.Config, error)
+
+// session keeps tasks found in git and Jira connection shared by steps of a command
+type session struct {
+	log   *zap.SugaredLogger
+	jira  jira.Jira
+	git   git.Git
+	tag   string
+	tasks []git.Task
+
+	versionTemplate *template.Template
+	// commentTemplate is nil for commands without comment flags
+	commentTemplate *template.Template
+}
+
+// requireFlags checks if all given flags are set, it's used instead of required flags,
+// because flags shared by all commands are required only by some of them
+func requireFlags(names ...string) func(c *cobra.Command, _ []string) error {
+	return func(c *cobra.Command, _ []string) error {
+		var missing []string
+		for _, name := range names {
+			if !c.Flags().Changed(name) {
+				missing = append(missing, `"`+name+`"`)
+			}
+		}
+		if len(missing) > 0 {
+			return errors.Errorf("required flag(s) %s not set", strings.Join(missing, ", "))
+		}
+
+		return nil
+	}
+}
+
+// newSession finds tasks in git and connects to Jira, errors are logged, so caller only has to exit
+func newSession(ctx context.Context, c *cobra.Command, log *zap.SugaredLogger, getConfig configGetter) (*session, error) {
+	s := &session{
+		log: log,
+		tag: c.Flag("tag").Value.String(),
+	}
+
+	var err error
+	s.versionTemplate, err = jira.ParseVersionTemplate(c.Flag("version-template").Value.String())
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing version-template param %+v", err)
+		return nil, err
+	}
+	if f := c.Flags().Lookup("comment-template"); f != nil {
+		s.commentTemplate, err = jira.ParseCommentTemplate(f.Value.String())
+		if err != nil {
+			log.Errorf("[JIRA-VERSIONER] error while parsing comment-template param %+v", err)
+			return nil, err
+		}
+	}
+
+	gitDir := c.Flag("dir").Value.String()
+	gitSettings, err := getGitSettings(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing git params %+v", err)
+		return nil, err
+	}
+	jiraConfig, err := getConfig(c, log)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing jira params %+v", err)
+		return nil, err
+	}
+
+	log.Debugf(
+		"[JIRA-VERSIONER] starting with parameters: %+v",
+		map[string]interface{}{
+			"jiraConfig":  jiraConfig,
+			"gitDir":      gitDir,
+			"tag":         s.tag,
+			"gitSettings": gitSettings,
+		},
+	)
+	log.Infof("[JIRA-VERSIONER] git directory: %s", gitDir)
+
+	s.git = git.New(gitDir, gitSettings, log)
+	s.tasks, err = s.git.GetTaskDetails(s.tag)
+	if err != nil {
+		log.Errorf("[GIT] error while getting tasks since latest commit %+v", err)
+		return nil, err
+	}
+	for _, task := range s.tasks {
+		log.Infof("[GIT] found task %s (%s)", task.Key, strings.Join(task.Types, ", "))
+	}
+
+	s.jira, err = jira.New(ctx, &jiraConfig)
+	if err != nil {
+		logJiraError(log, "error while connecting to jira server", err)
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// keys gets keys of all found tasks
+func (s *session) keys() []string {
+	keys := make([]string, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		keys = append(keys, task.Key)
+	}
+
+	return keys
+}
+
+// versionName uses jira-version flag if set, otherwise renders version name template
+func (s *session) versionName(c *cobra.Command) (string, error) {
+	version := c.Flag("jira-version").Value.String()
+	if version != "" {
+		return version, jira.ValidateVersionName(version)
+	}
+
+	branch, err := s.git.GetBranch()
+	if err != nil {
+		return "", errors.Wrap(err, "can't get git branch")
+	}
+
+	return jira.VersionName(s.versionTemplate, jira.NewVersionNameData(s.tag, s.jira.Project.Key, branch, time.Now()))
+}
+
+// createVersion creates version or reuses existing one
+func (s *session) createVersion(ctx context.Context, c *cobra.Command) error {
+	version, err := s.versionName(c)
+	if err != nil {
+		s.log.Errorf("[VERSION] error while naming version: %s", err)
+		return err
+	}
+
+	_, err = s.jira.CreateVersion(ctx, version, describeTasks(s.tasks))
+	if err != nil {
+		logJiraError(s.log, "error while creating version", err)
+		return err
+	}
+
+	return nil
+}
+
+// useVersion selects existing version
+func (s *session) useVersion(ctx context.Context, c *cobra.Command) error {
+	version, err := s.versionName(c)
+	if err != nil {
+		s.log.Errorf("[VERSION] error while naming version: %s", err)
+		return err
+	}
+
+	_, err = s.jira.UseVersion(ctx, version)
+	if err != nil {
+		logJiraError(s.log, "error while getting version", err)
+		return err
+	}
+
+	return nil
+}
+
+// link links tasks to version, with sync flag version is also removed from tasks no longer in the range
+func (s *session) link(ctx context.Context, c *cobra.Command) (jira.Report, error) {
+	if c.Flag("sync").Value.String() != "true" {
+		return s.jira.LinkTasksToVersion(ctx, s.keys()), nil
+	}
+
+	report, err := s.jira.SyncTasksWithVersion(ctx, s.keys())
+	if err != nil {
+		s.log.Errorf("[VERSION] error while syncing tasks with version %+v", err)
+		return report, err
+	}
+
+	return report, nil
+}
+
+// release transitions and comments linked tasks if enabled by flags
+func (s *session) release(ctx context.Context, c *cobra.Command, report jira.Report) jira.Report {
+	if c.Flag("transition").Value.String() != "" {
+		report = s.jira.TransitionLinkedTasks(ctx, report)
+	}
+	if c.Flag("comment").Value.String() == "true" {
+		buildURL := c.Flag("build-url").Value.String()
+		report = s.jira.CommentLinkedTasks(ctx, report, s.commentTemplate, commentData(s.tasks, s.tag, buildURL))
+	}
+
+	return report
+}
+
+// finish prints summary and fails if the run was stopped before finishing
+func (s *session) finish(ctx context.Context, report jira.Report) error {
+	report.Retries = s.jira.RetryStats()
+	logReport(s.log, report)
+	if ctx.Err() != nil {
+		s.log.Errorf("[JIRA-VERSIONER] run stopped before finishing: %s", ctx.Err())
+		return ctx.Err()
+	}
+	s.log.Infof("[JIRA-VERSIONER] done ✅")
+
+	return nil
+}
+
+// isDryRun checks if command runs in dry run mode
+func isDryRun(c *cobra.Command) bool {
+	return c.Flag("dry-run").Value.String() == "true"
+}
+
+// commandLogger creates logger of the command, in dry run mode it writes to stderr, so stdout has only the plan
+func commandLogger(c *cobra.Command) *zap.SugaredLogger {
+	if isDryRun(c) {
+		return newStderrLogger()
+	}
+
+	return zap.NewExample().Sugar()
+}
+
+// planVersion prints whether version would be created or reused
+func (s *session) planVersion(ctx context.Context, c *cobra.Command) error {
+	version, err := s.versionName(c)
+	if err != nil {
+		s.log.Errorf("[VERSION] error while naming version: %s", err)
+		return err
+	}
+
+	plan, err := s.jira.PlanVersion(ctx, version)
+	if err != nil {
+		logJiraError(s.log, "error while planning version", err)
+		return err
+	}
+
+	return printPlan(os.Stdout, plan)
+}
+
+// planLink prints which tasks would be linked to version, version has to exist unless createVersion is set,
+// with release transitions and comments of linked tasks are planned as well
+func (s *session) planLink(ctx context.Context, c *cobra.Command, createVersion, release bool) error {
+	version, err := s.versionName(c)
+	if err != nil {
+		s.log.Errorf("[VERSION] error while naming version: %s", err)
+		return err
+	}
+
+	plan := jira.Plan{Version: version}
+	if createVersion {
+		plan, err = s.jira.PlanVersion(ctx, version)
+	} else {
+		_, err = s.jira.UseVersion(ctx, version)
+	}
+	if err != nil {
+		logJiraError(s.log, "error while planning version", err)
+		return err
+	}
+
+	plan, err = s.jira.PlanLink(ctx, plan, s.keys(), c.Flag("sync").Value.String() == "true")
+	if err != nil {
+		logJiraError(s.log, "error while planning tasks", err)
+		return err
+	}
+	if release {
+		// Jira runs in dry run mode, so transitions and comments are only checked with read-only requests
+		plan.Release = s.release(ctx, c, plan.LinkedReport())
+	}
+
+	return printPlan(os.Stdout, plan)
+}
+
+// planRelease prints whether version would be released and what would happen with its linked tasks
+func (s *session) planRelease(ctx context.Context, c *cobra.Command) error {
+	err := s.useVersion(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	report, err := s.jira.LinkedTasksReport(ctx, s.keys())
+	if err != nil {
+		logJiraError(s.log, "error while getting tasks linked to version", err)
+		return err
+	}
+	// Jira runs in dry run mode, so transitions and comments are only checked with read-only requests
+	report = s.release(ctx, c, report)
+
+	return printPlan(os.Stdout, s.jira.PlanRelease(report))
+}
+
+// printPlan prints plan one change per line
+func printPlan(w io.Writer, plan interface{ Lines() []string }) error {
+	for _, line := range plan.Lines() {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
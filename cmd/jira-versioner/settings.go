@@ -0,0 +1,106 @@
+package main
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/psmarcin/jira-versioner/pkg/git"
+	"github.com/spf13/cobra"
+)
+
+// addGitSettingsFlags registers flags controlling which commits and issue keys are taken into account
+func addGitSettingsFlags(c *cobra.Command) {
+	c.PersistentFlags().StringSlice("ignore-author", nil, "Skip commits made by given author email, can be repeated")
+	c.PersistentFlags().StringSlice("ignore-subject", nil, "Skip commits which subject matches given regular expression, can be repeated")
+	c.PersistentFlags().Bool("ignore-reverted", false, "Skip commits reverted within the range together with reverting commits")
+	c.PersistentFlags().StringSlice("ignore-key", nil, "Never link given Jira issue key, can be repeated")
+	c.PersistentFlags().StringSlice("commit-type", nil, "Only Conventional Commits of given type contribute tasks, example: feat,fix")
+	c.PersistentFlags().String("task-order", string(git.OrderHistory), "Order of found tasks: history (first appearance in history, oldest first) or key")
+	c.PersistentFlags().Bool("key-case-sensitive", false, "Accept only uppercase issue keys, otherwise keys like jr-123 are uppercased")
+	c.PersistentFlags().Bool("key-underscore", false, "Accept underscore as issue key separator, example: JR_123")
+	c.PersistentFlags().Bool("key-space", false, "Accept space as issue key separator, example: JR 123")
+	c.PersistentFlags().Bool("key-leading-zeros", false, "Accept issue numbers with leading zeros, example: JR-0123")
+}
+
+// getGitSettings builds git settings from command flags
+func getGitSettings(c *cobra.Command) (git.Settings, error) {
+	var settings git.Settings
+	var err error
+
+	settings.Ignore, err = getIgnoreRules(c)
+	if err != nil {
+		return settings, err
+	}
+	settings.CommitTypes, err = c.Flags().GetStringSlice("commit-type")
+	if err != nil {
+		return settings, err
+	}
+	settings.Order, err = git.ParseOrder(c.Flag("task-order").Value.String())
+	if err != nil {
+		return settings, err
+	}
+	settings.Keys, err = getKeyFormat(c)
+	if err != nil {
+		return settings, err
+	}
+
+	return settings, nil
+}
+
+// getIgnoreRules builds git ignore rules from command flags
+func getIgnoreRules(c *cobra.Command) (git.IgnoreRules, error) {
+	var rules git.IgnoreRules
+	var err error
+
+	rules.AuthorEmails, err = c.Flags().GetStringSlice("ignore-author")
+	if err != nil {
+		return rules, err
+	}
+	rules.Keys, err = c.Flags().GetStringSlice("ignore-key")
+	if err != nil {
+		return rules, err
+	}
+	rules.Reverted, err = c.Flags().GetBool("ignore-reverted")
+	if err != nil {
+		return rules, err
+	}
+
+	subjects, err := c.Flags().GetStringSlice("ignore-subject")
+	if err != nil {
+		return rules, err
+	}
+	for _, subject := range subjects {
+		re, compileErr := regexp.Compile(subject)
+		if compileErr != nil {
+			return rules, errors.Wrapf(compileErr, "invalid ignore-subject pattern %s", subject)
+		}
+		rules.SubjectPatterns = append(rules.SubjectPatterns, re)
+	}
+
+	return rules, nil
+}
+
+// getKeyFormat builds issue key format from command flags
+func getKeyFormat(c *cobra.Command) (git.KeyFormat, error) {
+	var format git.KeyFormat
+	var err error
+
+	format.CaseSensitive, err = c.Flags().GetBool("key-case-sensitive")
+	if err != nil {
+		return format, err
+	}
+	format.Underscore, err = c.Flags().GetBool("key-underscore")
+	if err != nil {
+		return format, err
+	}
+	format.Space, err = c.Flags().GetBool("key-space")
+	if err != nil {
+		return format, err
+	}
+	format.LeadingZeros, err = c.Flags().GetBool("key-leading-zeros")
+	if err != nil {
+		return format, err
+	}
+
+	return format, nil
+}
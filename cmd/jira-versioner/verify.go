@@ -0,0 +1,130 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/psmarcin/jira-versioner/pkg/git"
+	"github.com/psmarcin/jira-versioner/pkg/jira"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func newVerifyCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "verify <range>",
+		Short: "Check that commits in git revision range reference existing, open tasks of the project",
+		Long: `Check that commits in git revision range reference existing, open tasks of the project.
+It exits with non-zero code when any task is missing, closed or belongs to other project, so it can gate pull requests.`,
+		Example: "jira-versioner verify origin/main..HEAD -e jira@example.com -k pa$$wor0 -p 10003 -u https://example.atlassian.net",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: requireFlags(jiraCredentialFlags...),
+		Run:     verifyFunc,
+	}
+	c.Flags().StringSlice("allowed-project", nil, "Key of project tasks can belong to, can be repeated, default project of --jira-project")
+	c.Flags().StringSlice(
+		"disallowed-status",
+		nil,
+		"Status tasks can't be in, can be repeated, default any status of Done category",
+	)
+
+	return c
+}
+
+func verifyFunc(c *cobra.Command, args []string) {
+	log := zap.NewExample().Sugar()
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	var rules jira.VerifyRules
+	var err error
+	rules.Projects, err = c.Flags().GetStringSlice("allowed-project")
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing allowed-project param %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+	rules.DisallowedStatuses, err = c.Flags().GetStringSlice("disallowed-status")
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing disallowed-status param %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+	gitSettings, err := getGitSettings(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing git params %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+
+	g := git.New(c.Flag("dir").Value.String(), gitSettings, log)
+	tasks, err := g.GetRangeTaskDetails(args[0])
+	if err != nil {
+		log.Errorf("[GIT] error while getting tasks in range %s %+v", args[0], err)
+		defer exitWithError() //nolint
+		return
+	}
+	keys := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		log.Infof("[GIT] found task %s (%s)", task.Key, strings.Join(task.Types, ", "))
+		keys = append(keys, task.Key)
+	}
+
+	ctx, cancel, err := commandContext(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing timeout param %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+	defer cancel()
+
+	j, err := connectJira(ctx, c, log, nil)
+	if err != nil {
+		logJiraError(log, "error while connecting to jira server", err)
+		defer exitWithError() //nolint
+		return
+	}
+
+	report := j.VerifyTasks(ctx, keys, rules)
+	logVerifyReport(log, report)
+	if report.HasProblems() || ctx.Err() != nil {
+		defer exitWithError() //nolint
+		return
+	}
+	log.Infof("[JIRA-VERSIONER] all tasks verified ✅")
+}
+
+// logVerifyReport prints verification result grouped by status and details of tasks which didn't pass
+func logVerifyReport(log *zap.SugaredLogger, report jira.VerifyReport) {
+	statuses := []jira.VerifyStatus{
+		jira.VerifyStatusOK,
+		jira.VerifyStatusMissing,
+		jira.VerifyStatusClosed,
+		jira.VerifyStatusWrongProject,
+		jira.VerifyStatusFailed,
+	}
+
+	log.Infof("[JIRA-VERSIONER] verification summary:")
+	for _, status := range statuses {
+		keys := report.Keys(status)
+		log.Infof("[JIRA-VERSIONER] %s: %d %s", status, len(keys), strings.Join(keys, ", "))
+	}
+	for _, issue := range report.Issues {
+		switch issue.Status {
+		case jira.VerifyStatusOK:
+			continue
+		case jira.VerifyStatusClosed:
+			log.Warnf("[JIRA-VERSIONER] %s is in status %s", issue.Key, issue.IssueStatus)
+		case jira.VerifyStatusWrongProject:
+			log.Warnf("[JIRA-VERSIONER] %s belongs to project %s", issue.Key, issue.Project)
+		case jira.VerifyStatusMissing:
+			log.Warnf("[JIRA-VERSIONER] %s doesn't exist or account can't see it", issue.Key)
+		default:
+			if hint := explainJiraError(issue.Err); hint != "" {
+				log.Warnf("[JIRA-VERSIONER] %s failed: %s (%s)", issue.Key, issue.Err, hint)
+			} else {
+				log.Warnf("[JIRA-VERSIONER] %s failed: %s", issue.Key, issue.Err)
+			}
+		}
+	}
+}
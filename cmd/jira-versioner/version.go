@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/psmarcin/jira-versioner/pkg/jira"
+	"github.com/spf13/cobra"
+)
+
+func newVersionCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "version",
+		Short: "Manage existing Jira versions",
+	}
+
+	rename := &cobra.Command{
+		Use:     "rename <version> <new-name>",
+		Short:   "Rename version, e.g. after git tag was moved",
+		Example: "jira-versioner version rename v2.1.0 v2.1.1 -e jira@example.com -k pa$$wor0 -p 10003 -u https://example.atlassian.net",
+		Args:    cobra.ExactArgs(2),
+		PreRunE: requireFlags(jiraCredentialFlags...),
+		Run:     renameVersionFunc,
+	}
+
+	merge := &cobra.Command{
+		Use:   "merge <source> <target>",
+		Short: "Move all issues from source version to target version and delete source version",
+		Long: `Move all issues from source version to target version and delete source version.
+Source version is kept when any of its issues can't be moved.`,
+		Example: "jira-versioner version merge v2.1.0 v2.1.1 -e jira@example.com -k pa$$wor0 -p 10003 -u https://example.atlassian.net",
+		Args:    cobra.ExactArgs(2),
+		PreRunE: requireFlags(jiraCredentialFlags...),
+		Run:     mergeVersionFunc,
+	}
+	merge.Flags().String(
+		"jira-field",
+		jira.FieldFixVersions,
+		"Version field issues are moved in: fixVersions, versions (Affects Version) or customfield_XXXXX",
+	)
+
+	c.AddCommand(rename, merge)
+
+	return c
+}
+
+func renameVersionFunc(c *cobra.Command, args []string) {
+	log := commandLogger(c)
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	ctx, cancel, err := commandContext(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing timeout param %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+	defer cancel()
+
+	j, err := connectJira(ctx, c, log, nil)
+	if err != nil {
+		logJiraError(log, "error while connecting to jira server", err)
+		defer exitWithError() //nolint
+		return
+	}
+
+	version, err := j.RenameVersion(ctx, args[0], args[1])
+	if err != nil {
+		logJiraError(log, "error while renaming version", err)
+		defer exitWithError() //nolint
+		return
+	}
+	if isDryRun(c) {
+		err = printPlan(os.Stdout, jira.RenamePlan{Version: args[0], NewName: version.Name})
+		if err != nil {
+			defer exitWithError() //nolint
+		}
+		return
+	}
+	log.Infof("[JIRA-VERSIONER] version %s (%s) renamed to %s ✅", args[0], version.ID, version.Name)
+}
+
+func mergeVersionFunc(c *cobra.Command, args []string) {
+	log := commandLogger(c)
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	ctx, cancel, err := commandContext(c)
+	if err != nil {
+		log.Errorf("[JIRA-VERSIONER] error while parsing timeout param %+v", err)
+		defer exitWithError() //nolint
+		return
+	}
+	defer cancel()
+
+	j, err := connectJira(ctx, c, log, func(config *jira.Config) {
+		config.Field = c.Flag("jira-field").Value.String()
+		config.ValidateField = true
+	})
+	if err != nil {
+		logJiraError(log, "error while connecting to jira server", err)
+		defer exitWithError() //nolint
+		return
+	}
+
+	report, err := j.MergeVersion(ctx, args[0], args[1])
+	if err == nil && isDryRun(c) {
+		err = printPlan(os.Stdout, jira.MergePlan{Source: args[0], Report: report})
+		if err != nil {
+			defer exitWithError() //nolint
+		}
+		return
+	}
+	// versions may be missing, then no issue was processed and there is nothing to summarize
+	if len(report.Issues) > 0 {
+		log.Infof("[JIRA-VERSIONER] moved to %s: %d %s", args[1], len(report.Keys(jira.IssueStatusLinked)),
+			strings.Join(report.Keys(jira.IssueStatusLinked), ", "))
+		for _, issue := range report.Issues {
+			if issue.Status == jira.IssueStatusFailed {
+				log.Warnf("[JIRA-VERSIONER] %s failed: %s", issue.Key, issue.Err)
+			}
+		}
+	}
+	if err != nil {
+		logJiraError(log, "error while merging versions", err)
+		defer exitWithError() //nolint
+		return
+	}
+	log.Infof("[JIRA-VERSIONER] version %s merged into %s ✅", args[0], args[1])
+}
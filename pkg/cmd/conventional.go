@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"regexp"
+	"strings"
+)
+
+// conventionalRe matches Conventional Commits subject header, e.g. `feat(api)!: JR-4 description`
+var conventionalRe = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s`)
+
+// parseConventional fills commit type, scope and breaking change marker from its subject and body,
+// commits not following Conventional Commits are left untouched
+func parseConventional(commit *Commit) {
+	match := conventionalRe.FindStringSubmatch(commit.Subject)
+	if match == nil {
+		return
+	}
+
+	commit.Type = strings.ToLower(match[1])
+	commit.Scope = match[2]
+	commit.Breaking = match[3] == "!" ||
+		strings.Contains(commit.Body, "BREAKING CHANGE:") ||
+		strings.Contains(commit.Body, "BREAKING-CHANGE:")
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConventional(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit Commit
+		want   Commit
+	}{
+		{
+			name:   "should parse type",
+			commit: Commit{Subject: "feat: JR-4 description"},
+			want:   Commit{Subject: "feat: JR-4 description", Type: "feat"},
+		},
+		{
+			name:   "should parse type, scope and breaking marker",
+			commit: Commit{Subject: "Fix(api)!: JR-4 description"},
+			want:   Commit{Subject: "Fix(api)!: JR-4 description", Type: "fix", Scope: "api", Breaking: true},
+		},
+		{
+			name:   "should detect breaking change in body",
+			commit: Commit{Subject: "feat(api): JR-4", Body: "BREAKING CHANGE: removed endpoint"},
+			want:   Commit{Subject: "feat(api): JR-4", Body: "BREAKING CHANGE: removed endpoint", Type: "feat", Scope: "api", Breaking: true},
+		},
+		{
+			name:   "should leave non conventional commit untouched",
+			commit: Commit{Subject: "JR-4: description", Body: "BREAKING CHANGE: removed endpoint"},
+			want:   Commit{Subject: "JR-4: description", Body: "BREAKING CHANGE: removed endpoint"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parseConventional(&tt.commit)
+			assert.Equal(t, tt.want, tt.commit)
+		})
+	}
+}
@@ -7,52 +7,94 @@ import (
 	pslog "github.com/psmarcin/jira-versioner/pkg/log"
 )
 
+const (
+	// fieldSeparator separates fields of single commit in git log output
+	fieldSeparator = "\x1f"
+	// commitSeparator separates commits in git log output
+	commitSeparator = "\x1e"
+	// commitFieldsCount is number of fields in logFormat
+	commitFieldsCount = 4
+)
+
+// logFormat prints hash, author email, subject and body of each commit
+var logFormat = fmt.Sprintf("--pretty=format:%%H%[1]s%%ae%[1]s%%s%[1]s%%b%[2]s", fieldSeparator, commitSeparator)
+
 // Git keeps all dependency interface
 type Git struct {
 	PreviousTagGetter
 	CommitGetter
+	BranchGetter
 
 	log pslog.Logger
 }
 
 // Commit stores basic data about git commit
 type Commit struct {
-	Hash    string
+	Hash        string
+	AuthorEmail string
+	Subject     string
+	Body        string
+	// Message is subject and body joined together
 	Message string
+	// Type, Scope and Breaking are parsed from Conventional Commits subject, empty if commit doesn't follow it
+	Type     string
+	Scope    string
+	Breaking bool
 }
 
 type PreviousTagGetter func(name string, arg ...string) (string, error)
 type CommitGetter func(name string, arg ...string) (string, error)
+type BranchGetter func(name string, arg ...string) (string, error)
 
 // New creates Git with default dependencies
 func New(log pslog.Logger) Git {
 	return Git{
 		PreviousTagGetter: Exec,
 		CommitGetter:      Exec,
+		BranchGetter:      Exec,
 		log:               log,
 	}
 }
 
 // GetCommits gets all commits between current and previous tag
 func (c Git) GetCommits(currentTag, previousTag, gitPath string) ([]Commit, error) {
-	var commits []Commit
 	r := fmt.Sprintf("%s...%s", currentTag, previousTag)
 	c.log.Infof("[GIT] found tags: %s", r)
 
-	out, err := c.CommitGetter("git", "-C", gitPath, "log", "--pretty=format:\"%H;%s %b\"", "--no-notes", r)
+	return c.GetRangeCommits(r, gitPath)
+}
+
+// GetRangeCommits gets all commits in git revision range, example: origin/main..HEAD
+func (c Git) GetRangeCommits(revisionRange, gitPath string) ([]Commit, error) {
+	var commits []Commit
+
+	out, err := c.CommitGetter("git", "-C", gitPath, "log", logFormat, "--no-notes", revisionRange)
 	if err != nil {
 		return nil, err
 	}
 
-	resultLines := strings.Split(out, "\n")
-	for _, line := range resultLines {
-		l := strings.Split(line, ";")
-		if len(l) > 1 {
-			commits = append(commits, Commit{
-				Hash:    l[0],
-				Message: l[1],
-			})
+	for _, entry := range strings.Split(out, commitSeparator) {
+		fields := strings.Split(strings.TrimLeft(entry, "\n"), fieldSeparator)
+		if len(fields) < commitFieldsCount {
+			continue
+		}
+
+		subject := fields[2]
+		body := strings.TrimSpace(fields[3])
+		message := subject
+		if body != "" {
+			message = subject + " " + body
 		}
+
+		commit := Commit{
+			Hash:        fields[0],
+			AuthorEmail: fields[1],
+			Subject:     subject,
+			Body:        body,
+			Message:     message,
+		}
+		parseConventional(&commit)
+		commits = append(commits, commit)
 	}
 
 	return commits, nil
@@ -67,3 +109,18 @@ func (c Git) GetPreviousTag(tag, gitPath string) (string, error) {
 
 	return strings.TrimSpace(out), nil
 }
+
+// GetBranch gets name of currently checked out branch, empty if HEAD is detached
+func (c Git) GetBranch(gitPath string) (string, error) {
+	out, err := c.BranchGetter("git", "-C", gitPath, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	branch := strings.TrimSpace(out)
+	if branch == "HEAD" {
+		return "", nil
+	}
+
+	return branch, nil
+}
@@ -114,8 +114,8 @@ func TestGitCommand_GetCommits(t *testing.T) {
 			fields: fields{
 				PreviousTagGetter: nil,
 				CommitGetter: func(name string, arg ...string) (string, error) {
-					return `sha1;feat: JIR-1556 commit message
-sha2;fix: JIR-9899 commit message`, nil
+					return "sha1\x1fdev@example.com\x1ffeat: JIR-1556 commit message\x1f\x1e\n" +
+						"sha2\x1fdev@example.com\x1ffix: JIR-9899 commit message\x1f\x1e", nil
 				},
 			},
 			args: args{
@@ -124,12 +124,41 @@ sha2;fix: JIR-9899 commit message`, nil
 			},
 			want: []Commit{
 				{
-					Hash:    "sha1",
-					Message: "feat: JIR-1556 commit message",
+					Hash:        "sha1",
+					AuthorEmail: "dev@example.com",
+					Subject:     "feat: JIR-1556 commit message",
+					Message:     "feat: JIR-1556 commit message",
+					Type:        "feat",
 				},
 				{
-					Hash:    "sha2",
-					Message: "fix: JIR-9899 commit message",
+					Hash:        "sha2",
+					AuthorEmail: "dev@example.com",
+					Subject:     "fix: JIR-9899 commit message",
+					Message:     "fix: JIR-9899 commit message",
+					Type:        "fix",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should keep multiline body in message",
+			fields: fields{
+				PreviousTagGetter: nil,
+				CommitGetter: func(name string, arg ...string) (string, error) {
+					return "sha1\x1fdev@example.com\x1fRevert \"feat: JIR-1556\"\x1fThis reverts commit sha0.\n\nJIR-1\n\x1e", nil
+				},
+			},
+			args: args{
+				tag:         v110,
+				previousTag: v100,
+			},
+			want: []Commit{
+				{
+					Hash:        "sha1",
+					AuthorEmail: "dev@example.com",
+					Subject:     `Revert "feat: JIR-1556"`,
+					Body:        "This reverts commit sha0.\n\nJIR-1",
+					Message:     "Revert \"feat: JIR-1556\" This reverts commit sha0.\n\nJIR-1",
 				},
 			},
 			wantErr: false,
@@ -187,6 +216,47 @@ sha2;fix: JIR-9899 commit message`, nil
 	}
 }
 
+func TestGitCommand_GetBranch(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     string
+		err     error
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "should get branch name",
+			out:  "release/2.1\n",
+			want: "release/2.1",
+		},
+		{
+			name: "should return empty branch for detached HEAD",
+			out:  "HEAD\n",
+			want: "",
+		},
+		{
+			name:    "should return error",
+			err:     errors.New("not a git repository"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Git{
+				BranchGetter: func(name string, arg ...string) (string, error) {
+					return tt.out, tt.err
+				},
+			}
+			got, err := c.GetBranch(".")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetBranch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestNew(t *testing.T) {
 	log := zap.NewExample().Sugar()
 	defer func() {
@@ -1,7 +1,7 @@
 package git
 
 import (
-	"regexp"
+	"strings"
 
 	"github.com/psmarcin/jira-versioner/pkg/cmd"
 	pslog "github.com/psmarcin/jira-versioner/pkg/log"
@@ -11,53 +11,159 @@ import (
 type Git struct {
 	Path         string
 	Dependencies Getter
+	Settings     Settings
 	log          pslog.Logger
 }
 
+// Settings controls which commits and issue keys are taken into account
+type Settings struct {
+	Ignore IgnoreRules
+	// CommitTypes limits Conventional Commits types contributing keys, e.g. feat and fix, empty means all commits
+	CommitTypes []string
+	// Order of returned tasks, history order by default
+	Order Order
+	// Keys controls detection and normalization of issue keys
+	Keys KeyFormat
+}
+
+// Task is Jira issue key found in commits
+type Task struct {
+	Key string
+	// Types are unique Conventional Commits types of commits referencing the task
+	Types []string
+	// Commits are all commits referencing the task
+	Commits []cmd.Commit
+}
+
 // Getter is interface for GetTasks dependencies for easier mocking
 type Getter interface {
 	GetCommits(string, string, string) ([]cmd.Commit, error)
 	GetPreviousTag(string, string) (string, error)
+	GetBranch(string) (string, error)
+	GetRangeCommits(string, string) ([]cmd.Commit, error)
 }
 
 // New creates Git with default dependencies
-func New(path string, log pslog.Logger) Git {
+func New(path string, settings Settings, log pslog.Logger) Git {
 	command := cmd.New(log)
 	return Git{
-		path,
-		command,
-		log,
+		Path:         path,
+		Dependencies: command,
+		Settings:     settings,
+		log:          log,
 	}
 }
 
 // GetTasks gets list of Jira taskIDs from commits
 func (g *Git) GetTasks(tag string) ([]string, error) {
-	var taskMap = make(map[string]struct{})
 	var tasks []string
 
-	previousTag, err := g.Dependencies.GetPreviousTag(tag, g.Path)
+	details, err := g.GetTaskDetails(tag)
 	if err != nil {
 		return tasks, err
 	}
+
+	for _, task := range details {
+		tasks = append(tasks, task.Key)
+	}
+	g.log.Debugf("[GIT] found tags: %s", tasks)
+	return tasks, nil
+}
+
+// GetTaskDetails gets list of Jira tasks together with commits referencing them
+func (g *Git) GetTaskDetails(tag string) ([]Task, error) {
+	previousTag, err := g.Dependencies.GetPreviousTag(tag, g.Path)
+	if err != nil {
+		return nil, err
+	}
 	g.log.Debugf("[GIT] found previous tag: %s", previousTag)
 
 	commits, err := g.Dependencies.GetCommits(tag, previousTag, g.Path)
 	if err != nil {
 		return nil, err
 	}
+
+	return g.tasksFromCommits(commits), nil
+}
+
+// GetRangeTaskDetails gets list of Jira tasks referenced by commits in git revision range, example: origin/main..HEAD
+func (g *Git) GetRangeTaskDetails(revisionRange string) ([]Task, error) {
+	commits, err := g.Dependencies.GetRangeCommits(revisionRange, g.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.tasksFromCommits(commits), nil
+}
+
+// tasksFromCommits finds tasks in commits applying ignore rules, commit types and key format from settings
+func (g *Git) tasksFromCommits(commits []cmd.Commit) []Task {
+	var taskIndex = make(map[string]int)
+	// oldest keeps position of the oldest commit referencing the task, commits are listed newest first
+	var oldest = make(map[string]int)
+	var tasks []Task
+
 	g.log.Debugf("[GIT] found commits: %+v", commits)
 
-	re := regexp.MustCompile(`(\w+)-(\d+)`)
-	for _, commit := range commits {
-		issueID := string(re.Find([]byte(commit.Message)))
-		if issueID != "" {
-			taskMap[issueID] = struct{}{}
+	commits = g.Settings.Ignore.filterCommits(commits)
+	g.log.Debugf("[GIT] commits after applying ignore rules: %d", len(commits))
+
+	re := g.Settings.Keys.compile()
+	for position, commit := range commits {
+		if !g.Settings.isTypeAllowed(commit.Type) {
+			continue
+		}
+		issueID := g.Settings.Keys.find(re, commit.Message)
+		if issueID == "" {
+			continue
+		}
+		if g.Settings.Ignore.isKeyIgnored(issueID) {
+			g.log.Debugf("[GIT] ignoring task %s", issueID)
+			continue
 		}
+
+		i, ok := taskIndex[issueID]
+		if !ok {
+			i = len(tasks)
+			taskIndex[issueID] = i
+			tasks = append(tasks, Task{Key: issueID})
+		}
+		tasks[i].addCommit(commit)
+		oldest[issueID] = position
 	}
 
-	for taskID := range taskMap {
-		tasks = append(tasks, taskID)
+	sortTasks(tasks, g.Settings.Order, oldest)
+	return tasks
+}
+
+// isTypeAllowed checks if commit of given Conventional Commits type contributes keys
+func (s Settings) isTypeAllowed(commitType string) bool {
+	if len(s.CommitTypes) == 0 {
+		return true
 	}
-	g.log.Debugf("[GIT] found tags: %s", tasks)
-	return tasks, nil
+	for _, t := range s.CommitTypes {
+		if strings.EqualFold(t, commitType) {
+			return true
+		}
+	}
+	return false
+}
+
+// addCommit adds commit referencing the task and remembers its type
+func (t *Task) addCommit(commit cmd.Commit) {
+	t.Commits = append(t.Commits, commit)
+	if commit.Type == "" {
+		return
+	}
+	for _, existing := range t.Types {
+		if existing == commit.Type {
+			return
+		}
+	}
+	t.Types = append(t.Types, commit.Type)
+}
+
+// GetBranch gets name of currently checked out branch, empty if HEAD is detached
+func (g *Git) GetBranch() (string, error) {
+	return g.Dependencies.GetBranch(g.Path)
 }
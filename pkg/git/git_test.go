@@ -1,6 +1,7 @@
 package git
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/psmarcin/jira-versioner/pkg/cmd"
@@ -25,6 +26,18 @@ func (m *MockedGit) GetPreviousTag(tag, gitPath string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockedGit) GetRangeCommits(revisionRange, gitPath string) ([]cmd.Commit, error) {
+	args := m.Called(revisionRange, gitPath)
+
+	return args.Get(0).([]cmd.Commit), args.Error(1)
+}
+
+func (m *MockedGit) GetBranch(gitPath string) (string, error) {
+	args := m.Called(gitPath)
+
+	return args.String(0), args.Error(1)
+}
+
 func TestGit_GetTasks_ReturnTaskIDsFromCommitMessage(t *testing.T) {
 	log := zap.NewExample().Sugar()
 	defer func() {
@@ -50,9 +63,8 @@ func TestGit_GetTasks_ReturnTaskIDsFromCommitMessage(t *testing.T) {
 	}
 	got, err := g.GetTasks("v1.1.0")
 	assert.NoError(t, err)
-	assert.Len(t, got, 2)
-	assert.Contains(t, got, "JIR-15")
-	assert.Contains(t, got, "JIR-123")
+	// commits are newest first, so task of the second (older) commit appeared first
+	assert.Equal(t, []string{"JIR-15", "JIR-123"}, got)
 }
 
 // nolint:dupl // omit dupl because it's almost the same codes
@@ -82,8 +94,7 @@ func TestGit_GetTasks_ReturnTaskIDsFromCommitMessageOmitCommitsWithoutTaskID(t *
 	}
 	got, err := g.GetTasks("v1.1.0")
 	assert.NoError(t, err)
-	assert.Len(t, got, 1)
-	assert.Contains(t, got, "JIR-123")
+	assert.Equal(t, []string{"JIR-123"}, got)
 }
 
 // nolint:dupl // omit dupl because it's almost the same code
@@ -113,6 +124,143 @@ func TestGit_GetTasks_ReturnTaskIDsFromCommitMessageOmitDuplicatedTaskIDs(t *tes
 	}
 	got, err := g.GetTasks("v1.1.0")
 	assert.NoError(t, err)
-	assert.Len(t, got, 1)
-	assert.Contains(t, got, "JIR-123")
+	assert.Equal(t, []string{"JIR-123"}, got)
+}
+
+func TestGit_GetTasks_OmitIgnoredCommitsAndKeys(t *testing.T) {
+	log := zap.NewExample().Sugar()
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	commits := []cmd.Commit{
+		{Hash: "sha1", AuthorEmail: "dev@example.com", Subject: "feat: JIR-1 keep me", Message: "feat: JIR-1 keep me"},
+		{Hash: "sha2", AuthorEmail: "bot@example.com", Subject: "chore: JIR-2 bump deps", Message: "chore: JIR-2 bump deps"},
+		{Hash: "sha3", AuthorEmail: "dev@example.com", Subject: "chore(release): JIR-3", Message: "chore(release): JIR-3"},
+		{Hash: "sha4", AuthorEmail: "dev@example.com", Subject: "feat: JIR-4 denied", Message: "feat: JIR-4 denied"},
+		{Hash: "abcdef5", AuthorEmail: "dev@example.com", Subject: "feat: JIR-5 reverted", Message: "feat: JIR-5 reverted"},
+		{
+			Hash:        "sha6",
+			AuthorEmail: "dev@example.com",
+			Subject:     `Revert "feat: JIR-5 reverted"`,
+			Body:        "This reverts commit abcdef5.",
+			Message:     `Revert "feat: JIR-5 reverted" This reverts commit abcdef5.`,
+		},
+	}
+
+	m := new(MockedGit)
+	m.On("GetPreviousTag", "v1.1.0", ".").Return("v1.0.0", nil)
+	m.On("GetCommits", "v1.1.0", "v1.0.0", ".").Return(commits, nil)
+	g := &Git{
+		Path:         ".",
+		Dependencies: m,
+		Settings: Settings{
+			Ignore: IgnoreRules{
+				AuthorEmails:    []string{"BOT@example.com"},
+				SubjectPatterns: []*regexp.Regexp{regexp.MustCompile(`^chore\(release\)`)},
+				Reverted:        true,
+				Keys:            []string{"JIR-4"},
+			},
+		},
+		log: log,
+	}
+	got, err := g.GetTasks("v1.1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"JIR-1"}, got)
+}
+
+func TestGit_GetTaskDetails_FilterCommitTypesAndExposeTypes(t *testing.T) {
+	log := zap.NewExample().Sugar()
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	feat := cmd.Commit{Hash: "sha1", Message: "feat(api): JIR-1 add endpoint", Type: "feat", Scope: "api"}
+	fix := cmd.Commit{Hash: "sha2", Message: "fix: JIR-1 fix endpoint", Type: "fix"}
+	docs := cmd.Commit{Hash: "sha3", Message: "docs: JIR-2 describe endpoint", Type: "docs"}
+	plain := cmd.Commit{Hash: "sha4", Message: "JIR-3 not conventional"}
+
+	m := new(MockedGit)
+	m.On("GetPreviousTag", "v1.1.0", ".").Return("v1.0.0", nil)
+	m.On("GetCommits", "v1.1.0", "v1.0.0", ".").Return([]cmd.Commit{feat, fix, docs, plain}, nil)
+	g := &Git{
+		Path:         ".",
+		Dependencies: m,
+		Settings:     Settings{CommitTypes: []string{"feat", "fix"}},
+		log:          log,
+	}
+	got, err := g.GetTaskDetails("v1.1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []Task{{Key: "JIR-1", Types: []string{"feat", "fix"}, Commits: []cmd.Commit{feat, fix}}}, got)
+}
+
+func TestGit_GetRangeTaskDetails(t *testing.T) {
+	log := zap.NewExample().Sugar()
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	feat := cmd.Commit{Hash: "sha1", Message: "feat: JIR-2 add endpoint", Type: "feat"}
+	plain := cmd.Commit{Hash: "sha2", Message: "JIR-1 not conventional"}
+
+	m := new(MockedGit)
+	m.On("GetRangeCommits", "origin/main..HEAD", ".").Return([]cmd.Commit{feat, plain}, nil)
+	g := &Git{
+		Path:         ".",
+		Dependencies: m,
+		Settings:     Settings{Ignore: IgnoreRules{Keys: []string{"JIR-1"}}},
+		log:          log,
+	}
+	got, err := g.GetRangeTaskDetails("origin/main..HEAD")
+	assert.NoError(t, err)
+	assert.Equal(t, []Task{{Key: "JIR-2", Types: []string{"feat"}, Commits: []cmd.Commit{feat}}}, got)
+	m.AssertNotCalled(t, "GetPreviousTag", mock.Anything, mock.Anything)
+}
+
+func TestGit_GetTasks_ReturnTaskIDsInStableOrder(t *testing.T) {
+	log := zap.NewExample().Sugar()
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	commits := []cmd.Commit{
+		{Hash: "sha1", Message: "feat: JIR-10 newest"},
+		{Hash: "sha2", Message: "feat: ABC-7 middle"},
+		{Hash: "sha3", Message: "feat: JIR-9 oldest"},
+		{Hash: "sha4", Message: "fix: JIR-10 again"},
+	}
+
+	tests := []struct {
+		name  string
+		order Order
+		want  []string
+	}{
+		{
+			name:  "should sort by first appearance in history",
+			order: OrderHistory,
+			want:  []string{"JIR-10", "JIR-9", "ABC-7"},
+		},
+		{
+			name:  "should sort by project and issue number",
+			order: OrderKey,
+			want:  []string{"ABC-7", "JIR-9", "JIR-10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(MockedGit)
+			m.On("GetPreviousTag", "v1.1.0", ".").Return("v1.0.0", nil)
+			m.On("GetCommits", "v1.1.0", "v1.0.0", ".").Return(commits, nil)
+			g := &Git{
+				Path:         ".",
+				Dependencies: m,
+				Settings:     Settings{Order: tt.order},
+				log:          log,
+			}
+			got, err := g.GetTasks("v1.1.0")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
 }
@@ -0,0 +1,122 @@
+package git
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/psmarcin/jira-versioner/pkg/cmd"
+)
+
+// revertRe matches body of commits created with `git revert`
+var revertRe = regexp.MustCompile(`This reverts commit ([0-9a-f]{7,40})`)
+
+// IgnoreRules describes commits and issue keys which should never reach Jira
+type IgnoreRules struct {
+	// AuthorEmails skips commits made by any of given authors, e.g. dependency bots
+	AuthorEmails []string
+	// SubjectPatterns skips commits which subject matches any of given patterns
+	SubjectPatterns []*regexp.Regexp
+	// Reverted skips commits reverted within the range together with reverting commits
+	Reverted bool
+	// Keys is deny-list of issue keys
+	Keys []string
+}
+
+// filterCommits returns only commits not matching any ignore rule
+func (r IgnoreRules) filterCommits(commits []cmd.Commit) []cmd.Commit {
+	var reverted map[string]struct{}
+	if r.Reverted {
+		reverted = revertedCommits(commits)
+	}
+
+	filtered := make([]cmd.Commit, 0, len(commits))
+	for _, commit := range commits {
+		if _, ok := reverted[commit.Hash]; ok {
+			continue
+		}
+		if r.isAuthorIgnored(commit.AuthorEmail) || r.isSubjectIgnored(commit.Subject) {
+			continue
+		}
+		filtered = append(filtered, commit)
+	}
+
+	return filtered
+}
+
+// isKeyIgnored checks if issue key is on deny-list
+func (r IgnoreRules) isKeyIgnored(key string) bool {
+	for _, k := range r.Keys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r IgnoreRules) isAuthorIgnored(email string) bool {
+	for _, e := range r.AuthorEmails {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r IgnoreRules) isSubjectIgnored(subject string) bool {
+	for _, re := range r.SubjectPatterns {
+		if re.MatchString(subject) {
+			return true
+		}
+	}
+	return false
+}
+
+// revertedCommits finds reverting and reverted commits within given commits, reverting commit whose target is outside
+// of the range is kept. Revert which is itself reverted doesn't count, so revert of revert keeps the original commit.
+func revertedCommits(commits []cmd.Commit) map[string]struct{} {
+	// targets maps reverting commit to commit it reverts, revertedBy is the other way round
+	targets := make(map[string]string)
+	revertedBy := make(map[string][]string)
+	for _, commit := range commits {
+		match := revertRe.FindStringSubmatch(commit.Body)
+		if match == nil {
+			continue
+		}
+		for _, target := range commits {
+			if strings.HasPrefix(target.Hash, match[1]) {
+				targets[commit.Hash] = target.Hash
+				revertedBy[target.Hash] = append(revertedBy[target.Hash], commit.Hash)
+				break
+			}
+		}
+	}
+
+	reverted := make(map[string]struct{})
+	effective := make(map[string]bool)
+	for reverting, target := range targets {
+		if isRevertEffective(reverting, revertedBy, effective) {
+			reverted[reverting] = struct{}{}
+			reverted[target] = struct{}{}
+		}
+	}
+
+	return reverted
+}
+
+// isRevertEffective checks if commit isn't cancelled by any effective revert of it, results are cached in effective
+func isRevertEffective(hash string, revertedBy map[string][]string, effective map[string]bool) bool {
+	if result, ok := effective[hash]; ok {
+		return result
+	}
+
+	result := true
+	for _, reverting := range revertedBy[hash] {
+		if isRevertEffective(reverting, revertedBy, effective) {
+			result = false
+			break
+		}
+	}
+	effective[hash] = result
+
+	return result
+}
@@ -0,0 +1,55 @@
+package git
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/psmarcin/jira-versioner/pkg/cmd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevertedCommits(t *testing.T) {
+	feat := cmd.Commit{Hash: "aaaaaaa1", Message: "feat: JIR-1 add endpoint"}
+	revert := cmd.Commit{Hash: "bbbbbbb2", Body: "This reverts commit aaaaaaa1."}
+	reapply := cmd.Commit{Hash: "ccccccc3", Body: "This reverts commit bbbbbbb2."}
+	revertAgain := cmd.Commit{Hash: "ddddddd4", Body: "This reverts commit ccccccc3."}
+	outside := cmd.Commit{Hash: "eeeeeee5", Body: "This reverts commit fffffff6."}
+
+	tests := []struct {
+		name    string
+		commits []cmd.Commit
+		want    []string
+	}{
+		{
+			name:    "should drop reverted commit together with revert",
+			commits: []cmd.Commit{revert, feat},
+			want:    []string{"aaaaaaa1", "bbbbbbb2"},
+		},
+		{
+			name:    "should keep original commit re-applied by revert of revert",
+			commits: []cmd.Commit{reapply, revert, feat},
+			want:    []string{"bbbbbbb2", "ccccccc3"},
+		},
+		{
+			name:    "should drop original commit reverted again",
+			commits: []cmd.Commit{revertAgain, reapply, revert, feat},
+			want:    []string{"aaaaaaa1", "bbbbbbb2", "ccccccc3", "ddddddd4"},
+		},
+		{
+			name:    "should keep revert of commit outside of the range",
+			commits: []cmd.Commit{outside, feat},
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for hash := range revertedCommits(tt.commits) {
+				got = append(got, hash)
+			}
+			sort.Strings(got)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
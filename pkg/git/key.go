@@ -0,0 +1,55 @@
+package git
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// KeyFormat controls how issue keys are detected in commit messages, zero value accepts `JR-123` and `jr-123`
+type KeyFormat struct {
+	// CaseSensitive accepts only uppercase keys, otherwise keys like jr-123 are accepted and uppercased
+	CaseSensitive bool
+	// Underscore accepts JR_123 as JR-123
+	Underscore bool
+	// Space accepts "JR 123" as JR-123
+	Space bool
+	// LeadingZeros accepts issue numbers with leading zeros, JR-0123 is then normalized to JR-123
+	LeadingZeros bool
+}
+
+// compile builds regular expression matching project key and issue number
+func (f KeyFormat) compile() *regexp.Regexp {
+	separators := "-"
+	if f.Underscore {
+		separators += "_"
+	}
+	if f.Space {
+		separators += " "
+	}
+
+	flags := "(?i)"
+	if f.CaseSensitive {
+		flags = ""
+	}
+
+	return regexp.MustCompile(flags + `\b([A-Z][A-Z0-9_]*)[` + separators + `](\d+)\b`)
+}
+
+// find returns first valid issue key in message normalized to PROJECT-NUMBER form, empty if not found
+func (f KeyFormat) find(re *regexp.Regexp, message string) string {
+	for _, match := range re.FindAllStringSubmatch(message, -1) {
+		project, number := match[1], match[2]
+		if !f.LeadingZeros && strings.HasPrefix(number, "0") {
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil || n == 0 {
+			continue
+		}
+
+		return strings.ToUpper(project) + "-" + strconv.Itoa(n)
+	}
+
+	return ""
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyFormat_find(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  KeyFormat
+		message string
+		want    string
+	}{
+		{
+			name:    "should find uppercase key",
+			message: "feat: JR-123 description",
+			want:    "JR-123",
+		},
+		{
+			name:    "should uppercase lowercase key",
+			message: "feat: jr-123 description",
+			want:    "JR-123",
+		},
+		{
+			name:    "should skip lowercase key when case sensitive",
+			format:  KeyFormat{CaseSensitive: true},
+			message: "feat: jr-123 description",
+			want:    "",
+		},
+		{
+			name:    "should skip underscore separator by default",
+			message: "feat: JR_123 description",
+			want:    "",
+		},
+		{
+			name:    "should accept underscore separator",
+			format:  KeyFormat{Underscore: true},
+			message: "feat: JR_123 description",
+			want:    "JR-123",
+		},
+		{
+			name:    "should keep underscore in project key",
+			format:  KeyFormat{Underscore: true},
+			message: "feat: MY_PROJ-12 description",
+			want:    "MY_PROJ-12",
+		},
+		{
+			name:    "should accept space separator",
+			format:  KeyFormat{Space: true},
+			message: "feat: jr 123 description",
+			want:    "JR-123",
+		},
+		{
+			name:    "should reject leading zeros and take next key",
+			message: "feat: JR-0123 JR-45 description",
+			want:    "JR-45",
+		},
+		{
+			name:    "should normalize leading zeros when allowed",
+			format:  KeyFormat{LeadingZeros: true},
+			message: "feat: JR-0123 description",
+			want:    "JR-123",
+		},
+		{
+			name:    "should reject issue number zero",
+			format:  KeyFormat{LeadingZeros: true},
+			message: "feat: JR-0 description",
+			want:    "",
+		},
+		{
+			name:    "should not match key inside word",
+			message: "feat: abcJR-1x description",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.format.find(tt.format.compile(), tt.message)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
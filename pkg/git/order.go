@@ -0,0 +1,66 @@
+package git
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Order defines how extracted tasks are sorted
+type Order string
+
+const (
+	// OrderHistory sorts tasks by first appearance in history, task referenced by the oldest commit goes first
+	OrderHistory Order = "history"
+	// OrderKey sorts tasks by project key and then numerically by issue number
+	OrderKey Order = "key"
+)
+
+// ParseOrder validates order name
+func ParseOrder(name string) (Order, error) {
+	switch o := Order(name); o {
+	case OrderHistory, OrderKey:
+		return o, nil
+	case "":
+		return OrderHistory, nil
+	default:
+		return "", errors.Errorf("unknown task order %s, expected %s or %s", name, OrderHistory, OrderKey)
+	}
+}
+
+// sortTasks sorts tasks in place, oldest keeps position of the oldest commit of each task in newest first git log
+func sortTasks(tasks []Task, order Order, oldest map[string]int) {
+	if order == OrderKey {
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return lessKey(tasks[i].Key, tasks[j].Key)
+		})
+		return
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return oldest[tasks[i].Key] > oldest[tasks[j].Key]
+	})
+}
+
+// lessKey compares issue keys naturally, so JR-9 goes before JR-10
+func lessKey(a, b string) bool {
+	projectA, numberA := splitKey(a)
+	projectB, numberB := splitKey(b)
+	if projectA != projectB {
+		return projectA < projectB
+	}
+	return numberA < numberB
+}
+
+func splitKey(key string) (project string, number int) {
+	i := strings.LastIndex(key, "-")
+	if i < 0 {
+		return key, 0
+	}
+	number, err := strconv.Atoi(key[i+1:])
+	if err != nil {
+		return key, 0
+	}
+	return key[:i], number
+}
@@ -0,0 +1,161 @@
+package jira
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pkg/errors"
+)
+
+// releaseDateLayout is format of version release date returned by Jira
+const releaseDateLayout = "2006-01-02"
+
+// ArchiveStatus is result of archiving single version
+type ArchiveStatus string
+
+const (
+	// ArchiveStatusArchived means version was archived, or would be in dry run mode
+	ArchiveStatusArchived ArchiveStatus = "archived"
+	// ArchiveStatusFailed means version couldn't be archived
+	ArchiveStatusFailed ArchiveStatus = "failed"
+)
+
+// ArchivePolicy selects released versions to archive, when both OlderThan and KeepLast are set
+// version has to match both of them
+type ArchivePolicy struct {
+	// OlderThan archives versions released earlier than that, zero means any release date
+	OlderThan time.Duration
+	// KeepLast keeps given number of the most recently released versions, zero means none is kept
+	KeepLast int
+	// NamePattern limits versions to ones with matching name, nil means all versions
+	NamePattern *regexp.Regexp
+	// Now is reference time for OlderThan, current time if zero
+	Now time.Time
+}
+
+// ArchivedVersion is version selected for archiving
+type ArchivedVersion struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	ReleaseDate string        `json:"releaseDate,omitempty"`
+	Status      ArchiveStatus `json:"status"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// ArchiveReport is summary of archiving versions
+type ArchiveReport struct {
+	DryRun bool `json:"dryRun"`
+	// Matched is number of released, not archived versions matching name pattern
+	Matched  int               `json:"matched"`
+	Versions []ArchivedVersion `json:"versions"`
+}
+
+// HasFailures checks if any version couldn't be archived
+func (r ArchiveReport) HasFailures() bool {
+	for _, v := range r.Versions {
+		if v.Status == ArchiveStatusFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ArchiveVersions archives released project versions selected by policy
+func (j Jira) ArchiveVersions(ctx context.Context, policy ArchivePolicy) (ArchiveReport, error) {
+	report := ArchiveReport{DryRun: j.dryRun, Versions: []ArchivedVersion{}}
+	if policy.OlderThan <= 0 && policy.KeepLast <= 0 {
+		return report, errors.New("archive policy needs release age or number of versions to keep")
+	}
+
+	versions, err := j.getProjectVersions(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	released := releasedVersions(versions, policy.NamePattern)
+	report.Matched = len(released)
+
+	for _, v := range selectArchived(released, policy) {
+		archived := ArchivedVersion{ID: v.ID, Name: v.Name, ReleaseDate: v.ReleaseDate, Status: ArchiveStatusArchived}
+		archiveErr := j.archiveVersion(ctx, v)
+		if archiveErr != nil {
+			j.log.Warnf("[JIRA] can't archive version %s (%s)", v.Name, archiveErr)
+			archived.Status = ArchiveStatusFailed
+			archived.Error = archiveErr.Error()
+		}
+		report.Versions = append(report.Versions, archived)
+	}
+
+	return report, nil
+}
+
+// archiveVersion marks single version as archived
+func (j Jira) archiveVersion(ctx context.Context, v jira.Version) error {
+	j.log.Debugf("[JIRA] archiving version %s (%s)", v.Name, v.ID)
+	if j.dryRun {
+		return nil
+	}
+
+	_, res, err := j.Client.Version.UpdateWithContext(ctx, &jira.Version{ID: v.ID, Archived: true})
+	if err != nil {
+		return responseError(res, err)
+	}
+	j.log.Infof("[JIRA] version archived %s", v.Name)
+
+	return nil
+}
+
+// releasedVersions gets released and not yet archived versions matching pattern, the most recently released first,
+// versions without release date are treated as the oldest ones
+func releasedVersions(versions []jira.Version, pattern *regexp.Regexp) []jira.Version {
+	var released []jira.Version
+	for _, v := range versions {
+		if !v.Released || v.Archived {
+			continue
+		}
+		if pattern != nil && !pattern.MatchString(v.Name) {
+			continue
+		}
+		released = append(released, v)
+	}
+
+	// Jira lists versions in project order, reverse it, so newer versions win ties
+	for i, k := 0, len(released)-1; i < k; i, k = i+1, k-1 {
+		released[i], released[k] = released[k], released[i]
+	}
+	sort.SliceStable(released, func(a, b int) bool {
+		return released[a].ReleaseDate > released[b].ReleaseDate
+	})
+
+	return released
+}
+
+// selectArchived picks versions to archive from released versions ordered from the most recent one
+func selectArchived(released []jira.Version, policy ArchivePolicy) []jira.Version {
+	now := policy.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	cutoff := now.Add(-policy.OlderThan)
+
+	var selected []jira.Version
+	for i, v := range released {
+		if i < policy.KeepLast {
+			continue
+		}
+		if policy.OlderThan > 0 {
+			date, err := time.Parse(releaseDateLayout, v.ReleaseDate)
+			if err != nil || !date.Before(cutoff) {
+				// age of version without release date is unknown, so it's kept
+				continue
+			}
+		}
+		selected = append(selected, v)
+	}
+
+	return selected
+}
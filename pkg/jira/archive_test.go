@@ -0,0 +1,113 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectArchived(t *testing.T) {
+	now := time.Date(2021, time.April, 30, 0, 0, 0, 0, time.UTC)
+	versions := []jira.Version{
+		{ID: "1", Name: "v1.0.0", Released: true, ReleaseDate: "2021-01-10"},
+		{ID: "2", Name: "v1.1.0", Released: true, Archived: true, ReleaseDate: "2021-02-10"},
+		{ID: "3", Name: "web-1.0.0", Released: true, ReleaseDate: "2021-02-20"},
+		{ID: "4", Name: "v1.2.0", Released: true, ReleaseDate: "2021-03-10"},
+		{ID: "5", Name: "v1.3.0", Released: true, ReleaseDate: "2021-04-20"},
+		{ID: "6", Name: "v1.4.0"},
+		{ID: "7", Name: "v0.9.0", Released: true},
+	}
+	tests := []struct {
+		name   string
+		policy ArchivePolicy
+		want   []string
+	}{
+		{
+			name:   "should archive versions older than given age",
+			policy: ArchivePolicy{OlderThan: 30 * 24 * time.Hour},
+			want:   []string{"v1.2.0", "web-1.0.0", "v1.0.0"},
+		},
+		{
+			name:   "should archive versions beyond last releases",
+			policy: ArchivePolicy{KeepLast: 3},
+			want:   []string{"v1.0.0", "v0.9.0"},
+		},
+		{
+			name:   "should match both age and last releases",
+			policy: ArchivePolicy{OlderThan: 60 * 24 * time.Hour, KeepLast: 1},
+			want:   []string{"web-1.0.0", "v1.0.0"},
+		},
+		{
+			name:   "should archive only versions matching name pattern",
+			policy: ArchivePolicy{KeepLast: 1, NamePattern: regexp.MustCompile(`^v\d`)},
+			want:   []string{"v1.2.0", "v1.0.0", "v0.9.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.policy.Now = now
+
+			var got []string
+			for _, v := range selectArchived(releasedVersions(versions, tt.policy.NamePattern), tt.policy) {
+				got = append(got, v.Name)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJira_ArchiveVersions(t *testing.T) {
+	var archived []string
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/project/10000/versions": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `[
+				{"id": "10100", "name": "v1.0.0", "released": true, "releaseDate": "2021-01-10"},
+				{"id": "10101", "name": "v1.1.0", "released": true, "releaseDate": "2021-02-10"},
+				{"id": "10102", "name": "v1.2.0", "released": true, "releaseDate": "2021-03-10"}
+			]`)
+		},
+		"/rest/api/2/version/": func(w http.ResponseWriter, r *http.Request) {
+			var v jira.Version
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&v))
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.True(t, v.Archived)
+			if r.URL.Path == "/rest/api/2/version/10101" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			archived = append(archived, r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{}`)
+		},
+	})
+	j := newTestJira(t, server)
+
+	report, err := j.ArchiveVersions(context.Background(), ArchivePolicy{KeepLast: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Matched)
+	assert.Equal(t, []string{"/rest/api/2/version/10100"}, archived)
+	require.Len(t, report.Versions, 2)
+	assert.Equal(t, ArchiveStatusFailed, report.Versions[0].Status)
+	assert.Equal(t, ArchiveStatusArchived, report.Versions[1].Status)
+	assert.True(t, report.HasFailures())
+}
+
+func TestJira_ArchiveVersions_RequiresPolicy(t *testing.T) {
+	server := newTestServer(t, nil)
+	j := newTestJira(t, server)
+
+	_, err := j.ArchiveVersions(context.Background(), ArchivePolicy{})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pkg/errors"
+)
+
+// DefaultCommentTemplate is used when no comment template is provided
+const DefaultCommentTemplate = `Released in version {{.Version}}{{if .Tag}} (tag {{.Tag}}){{end}}.
+{{- if .Commits}}
+Commits: {{join .Commits ", "}}{{end}}
+{{- if .BuildURL}}
+Build: {{.BuildURL}}{{end}}`
+
+// CommentStatus is result of commenting single task
+type CommentStatus string
+
+const (
+	// CommentStatusDone means comment was added
+	CommentStatusDone CommentStatus = "commented"
+	// CommentStatusAlreadyDone means task already has comment about the version, so it was skipped
+	CommentStatusAlreadyDone CommentStatus = "already commented"
+	// CommentStatusFailed means comment couldn't be checked or added
+	CommentStatusFailed CommentStatus = "failed"
+)
+
+// CommentData is available in comment template
+type CommentData struct {
+	Version  string
+	Tag      string
+	Issue    string
+	Commits  []string
+	BuildURL string
+}
+
+// ParseCommentTemplate parses comment template, `join` function is available next to builtin ones
+func ParseCommentTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultCommentTemplate
+	}
+
+	t, err := template.New("comment").Funcs(template.FuncMap{"join": strings.Join}).Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't parse comment template")
+	}
+
+	return t, nil
+}
+
+// CommentLinkedTasks adds comment with release information to tasks linked to version,
+// tasks already having such comment are skipped, so re-runs don't duplicate comments
+func (j Jira) CommentLinkedTasks(ctx context.Context, report Report, t *template.Template, data map[string]CommentData) Report {
+	for i := range report.Issues {
+		issue := &report.Issues[i]
+		if issue.Status != IssueStatusLinked && issue.Status != IssueStatusAlreadyLinked {
+			continue
+		}
+
+		d := data[issue.Key]
+		d.Issue = issue.Key
+		d.Version = j.Version.Name
+
+		issue.Comment, issue.CommentErr = j.commentTask(ctx, issue.Key, t, d)
+		if issue.CommentErr != nil {
+			j.log.Warnf("[JIRA] can't comment task %s (%s)", issue.Key, issue.CommentErr)
+		}
+	}
+
+	return report
+}
+
+// commentTask adds comment to single task unless it already has one about the version
+func (j Jira) commentTask(ctx context.Context, taskID string, t *template.Template, data CommentData) (CommentStatus, error) {
+	var body bytes.Buffer
+	err := t.Execute(&body, data)
+	if err != nil {
+		return CommentStatusFailed, errors.Wrapf(err, "can't render comment for task %s", taskID)
+	}
+	marker := commentMarker(data.Version)
+
+	issue, res, err := j.Client.Issue.GetWithContext(ctx, taskID, &jira.GetQueryOptions{Fields: "comment"})
+	if err != nil {
+		return CommentStatusFailed, errors.Wrapf(responseError(res, err), "can't get comments of task %s", taskID)
+	}
+	if issue.Fields != nil && issue.Fields.Comments != nil {
+		for _, c := range issue.Fields.Comments.Comments {
+			if c != nil && strings.Contains(c.Body, marker) {
+				j.log.Infof("[JIRA] task %s already has comment about %s, skipping", taskID, data.Version)
+				return CommentStatusAlreadyDone, nil
+			}
+		}
+	}
+
+	if !j.dryRun {
+		_, res, err = j.Client.Issue.AddCommentWithContext(ctx, taskID, &jira.Comment{
+			Body: body.String() + "\n\n" + marker,
+		})
+		if err != nil {
+			return CommentStatusFailed, errors.Wrapf(responseError(res, err), "can't add comment to task %s", taskID)
+		}
+	}
+	j.log.Infof("[JIRA] task %s commented", taskID)
+
+	return CommentStatusDone, nil
+}
+
+// commentMarker identifies comments added for given version
+func commentMarker(version string) string {
+	return fmt.Sprintf("_jira-versioner: %s_", version)
+}
@@ -0,0 +1,54 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJira_CommentLinkedTasks_Idempotent(t *testing.T) {
+	// server keeps added comments, so second run sees comment of the first one
+	type comment struct {
+		Body string `json:"body"`
+	}
+	var comments []comment
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/issue/JR-1": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "comment", r.URL.Query().Get("fields"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"key":    "JR-1",
+				"fields": map[string]interface{}{"comment": map[string]interface{}{"comments": comments}},
+			})
+		},
+		"/rest/api/2/issue/JR-1/comment": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			var c comment
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&c))
+			comments = append(comments, c)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(c)
+		},
+	})
+	j := newTestJira(t, server)
+	tmpl, err := ParseCommentTemplate("")
+	require.NoError(t, err)
+	data := map[string]CommentData{"JR-1": {Tag: "v1.0.0"}}
+
+	for _, want := range []CommentStatus{CommentStatusDone, CommentStatusAlreadyDone} {
+		report := Report{Version: "v1.0.0"}
+		report.add("JR-1", IssueStatusAlreadyLinked, nil)
+
+		report = j.CommentLinkedTasks(context.Background(), report, tmpl, data)
+
+		require.NoError(t, report.Issues[0].CommentErr)
+		assert.Equal(t, want, report.Issues[0].Comment)
+	}
+	require.Len(t, comments, 1)
+	assert.Equal(t, "Released in version v1.0.0 (tag v1.0.0).\n\n_jira-versioner: v1.0.0_", comments[0].Body)
+}
@@ -0,0 +1,150 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrUnauthorized means Jira rejected credentials
+	ErrUnauthorized = errors.New("jira rejected credentials")
+	// ErrForbidden means account is not allowed to perform the operation
+	ErrForbidden = errors.New("jira account has no permission")
+	// ErrNotFound means issue, project or version doesn't exist or account can't see it
+	ErrNotFound = errors.New("jira resource not found")
+	// ErrVersionConflict means version with the same name already exists in the project
+	ErrVersionConflict = errors.New("jira version already exists")
+	// ErrRateLimited means Jira rejected request because of rate limiting
+	ErrRateLimited = errors.New("jira rate limit exceeded")
+	// ErrVersionNotCreated means version has no ID, because it wasn't created, e.g. in dry run mode
+	ErrVersionNotCreated = errors.New("jira version not created")
+)
+
+// TransportError means request didn't get any response from Jira, e.g. DNS error, connection refused or timeout
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("jira request failed without response: %s", e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPError means Jira responded with status code outside of 2xx range,
+// Messages and Fields are parsed from Jira `errorMessages` and `errors` response fields,
+// use errors.Is with ErrUnauthorized, ErrForbidden, ErrNotFound, ErrVersionConflict or ErrRateLimited to check the kind
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+	Messages   []string
+	Fields     map[string]string
+	Err        error
+}
+
+func (e *HTTPError) Error() string {
+	details := e.details()
+	if details == "" && len(e.Body) > 0 {
+		details = string(e.Body)
+	}
+	if details == "" {
+		details = e.Err.Error()
+	}
+	return fmt.Sprintf("jira responded with status %d: %s", e.StatusCode, details)
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// Is allows to check kind of error with errors.Is
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrVersionConflict:
+		return e.StatusCode == http.StatusBadRequest && strings.Contains(e.details(), "already exists")
+	default:
+		return false
+	}
+}
+
+// details joins all Jira error messages, field errors are sorted by field name
+func (e *HTTPError) details() string {
+	parts := make([]string, 0, len(e.Messages)+len(e.Fields))
+	parts = append(parts, e.Messages...)
+
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		parts = append(parts, fmt.Sprintf("%s: %s", field, e.Fields[field]))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// responseError classifies error of Jira request into TransportError or HTTPError,
+// response may be nil when request failed at transport level
+func responseError(res *jira.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+	if res == nil || res.Response == nil {
+		return &TransportError{Err: err}
+	}
+
+	httpErr := &HTTPError{
+		StatusCode: res.StatusCode,
+		Err:        err,
+	}
+	if res.Body != nil {
+		// body may be already consumed by go-jira, then error details are kept in Err
+		body, readErr := ioutil.ReadAll(res.Body)
+		if readErr == nil {
+			httpErr.Body = body
+		}
+		_ = res.Body.Close()
+	}
+	httpErr.parse()
+
+	return httpErr
+}
+
+// parse reads Jira error messages from body or from go-jira error which already consumed the body
+func (e *HTTPError) parse() {
+	var jiraErr *jira.Error
+	if errors.As(e.Err, &jiraErr) {
+		e.Messages = jiraErr.ErrorMessages
+		e.Fields = jiraErr.Errors
+	}
+	if len(e.Body) == 0 {
+		return
+	}
+
+	var body struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if json.Unmarshal(e.Body, &body) == nil {
+		e.Messages = body.ErrorMessages
+		e.Fields = body.Errors
+	}
+}
@@ -0,0 +1,105 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FieldFixVersions is default field tasks are linked with
+	FieldFixVersions = "fixVersions"
+	// FieldAffectsVersions is Affects Version field
+	FieldAffectsVersions = "versions"
+	// FieldLabels is labels field
+	FieldLabels = "labels"
+
+	customFieldPrefix = "customfield_"
+)
+
+// fieldSchema is part of field metadata describing its type
+type fieldSchema struct {
+	Type  string `json:"type"`
+	Items string `json:"items"`
+}
+
+// fieldMeta is field metadata returned by Jira field endpoint
+type fieldMeta struct {
+	ID     string      `json:"id"`
+	Name   string      `json:"name"`
+	Schema fieldSchema `json:"schema"`
+}
+
+// validateField checks if version field exists in Jira and holds multiple versions
+func (j Jira) validateField(ctx context.Context) error {
+	var fields []fieldMeta
+
+	req, err := j.Client.NewRequestWithContext(ctx, "GET", "/rest/api/2/field", nil)
+	if err != nil {
+		return errors.Wrapf(err, "can't create Jira request to %s", "/rest/api/2/field")
+	}
+	res, err := j.Client.Do(req, &fields)
+	if err != nil {
+		return errors.Wrap(responseError(res, err), "can't get field metadata")
+	}
+
+	for _, field := range fields {
+		if field.ID != j.field {
+			continue
+		}
+		if field.Schema.Type != "array" || field.Schema.Items != "version" {
+			return errors.Errorf("field %s is not a multiple versions field (%s of %s)", j.field, field.Schema.Type, field.Schema.Items)
+		}
+
+		j.log.Debugf("[JIRA] field %s found (%s)", j.field, field.Name)
+		return nil
+	}
+
+	return errors.Errorf("field %s is not available in Jira", j.field)
+}
+
+// jqlField returns name of version field used in JQL queries
+func (j Jira) jqlField() string {
+	switch {
+	case j.field == FieldFixVersions:
+		return "fixVersion"
+	case j.field == FieldAffectsVersions:
+		return "affectedVersion"
+	case strings.HasPrefix(j.field, customFieldPrefix):
+		return fmt.Sprintf("cf[%s]", strings.TrimPrefix(j.field, customFieldPrefix))
+	default:
+		return j.field
+	}
+}
+
+// getIssueVersions gets current versions of task from configured field
+func (j Jira) getIssueVersions(ctx context.Context, taskID string) ([]jira.FixVersion, error) {
+	var issue struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	var versions []jira.FixVersion
+
+	req, err := j.Client.NewRequestWithContext(ctx, "GET", "/rest/api/2/issue/"+taskID+"?fields="+j.field, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't create Jira request to %s", "/rest/api/2/issue/"+taskID)
+	}
+	res, err := j.Client.Do(req, &issue)
+	if err != nil {
+		return nil, errors.Wrapf(responseError(res, err), "can't get %s of task %s", j.field, taskID)
+	}
+
+	raw, ok := issue.Fields[j.field]
+	if !ok || string(raw) == "null" {
+		return versions, nil
+	}
+	err = json.Unmarshal(raw, &versions)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't parse %s of task %s", j.field, taskID)
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,126 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestJira_validateField(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		wantErr string
+	}{
+		{
+			name:  "should accept Affects Version field",
+			field: FieldAffectsVersions,
+		},
+		{
+			name:  "should accept custom multiple versions field",
+			field: "customfield_10020",
+		},
+		{
+			name:    "should reject single version field",
+			field:   "customfield_10010",
+			wantErr: "field customfield_10010 is not a multiple versions field (version of )",
+		},
+		{
+			name:    "should reject missing field",
+			field:   "customfield_99999",
+			wantErr: "field customfield_99999 is not available in Jira",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, nil)
+			j := newTestJira(t, server)
+			j.field = tt.field
+
+			err := j.validateField(context.Background())
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNew_ValidateField(t *testing.T) {
+	tests := []struct {
+		name          string
+		field         string
+		validateField bool
+		wantRequest   bool
+		wantErr       bool
+	}{
+		{
+			name:          "should skip default field",
+			field:         FieldFixVersions,
+			validateField: true,
+		},
+		{
+			name:  "should skip field which isn't edited",
+			field: "customfield_10010",
+		},
+		{
+			name:          "should validate edited field",
+			field:         "customfield_10010",
+			validateField: true,
+			wantRequest:   true,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := false
+			server := newTestServer(t, map[string]http.HandlerFunc{
+				"/rest/api/2/field": func(w http.ResponseWriter, r *http.Request) {
+					requested = true
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(fieldsResponse))
+				},
+			})
+
+			_, err := New(context.Background(), &Config{
+				ProjectID:     "10000",
+				BaseURL:       server.URL,
+				Log:           zap.NewNop().Sugar(),
+				Field:         tt.field,
+				ValidateField: tt.validateField,
+			})
+
+			assert.Equal(t, tt.wantRequest, requested)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestJira_jqlField(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: FieldFixVersions, want: "fixVersion"},
+		{field: FieldAffectsVersions, want: "affectedVersion"},
+		{field: "customfield_10020", want: "cf[10020]"},
+		{field: "Deployed in", want: "Deployed in"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			j := Jira{field: tt.field}
+
+			assert.Equal(t, tt.want, j.jqlField())
+		})
+	}
+}
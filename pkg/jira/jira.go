@@ -1,7 +1,8 @@
 package jira
 
 import (
-	"io/ioutil"
+	"context"
+	"encoding/json"
 	"strconv"
 	"time"
 
@@ -20,16 +21,46 @@ type Jira struct {
 	Version   *jira.Version
 	log       pslog.Logger
 	dryRun    bool
+
+	fixVersionMode FixVersionMode
+	field          string
+	notifyUsers    bool
+	transition     string
+	transitionFrom []string
+	addLabels      []string
+	removeLabels   []string
+	retries        *retryStats
 }
 
 type UpdatePayload struct {
 	Update UpdateTypePayload `json:"update"`
 }
+
+// UpdateTypePayload keeps edit operations of version field and labels, Field is used as JSON key of version operations
 type UpdateTypePayload struct {
-	FixVersions []AddFixedVersion `json:"fixVersions"`
+	Field    string
+	Versions []VersionOperation
+	Labels   []LabelOperation
+}
+
+// MarshalJSON puts operations under field names, e.g. {"fixVersions": [{"add": {"id": "10000"}}]},
+// fields without operations are omitted
+func (u UpdateTypePayload) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{})
+	if len(u.Versions) > 0 {
+		fields[u.Field] = u.Versions
+	}
+	if len(u.Labels) > 0 {
+		fields[FieldLabels] = u.Labels
+	}
+	return json.Marshal(fields)
 }
-type AddFixedVersion struct {
-	Add VersionID `json:"add"`
+
+// VersionOperation is single edit operation on version field, only one of them should be set
+type VersionOperation struct {
+	Add    *VersionID  `json:"add,omitempty"`
+	Remove *VersionID  `json:"remove,omitempty"`
+	Set    []VersionID `json:"set,omitempty"`
 }
 
 type VersionID struct {
@@ -44,19 +75,60 @@ type Config struct {
 	Log            pslog.Logger
 	DryRun         bool
 	HTTPMaxRetries int
+	// HTTPTimeout limits time of single HTTP request, zero means no limit
+	HTTPTimeout time.Duration
+	// RetryMaxWait limits single wait between retries, also when Jira asks to wait longer, DefaultRetryMaxWait if zero
+	RetryMaxWait   time.Duration
+	FixVersionMode FixVersionMode
+	// Field is version field tasks are linked with, fixVersions by default
+	Field string
+	// ValidateField checks on connect that custom Field holds multiple versions, it's needed only when Field is edited
+	ValidateField bool
+	// DisableNotifications stops Jira from sending emails to watchers on issue edit, requires admin permission
+	DisableNotifications bool
+	// Transition is name of workflow transition applied to linked tasks, empty means no transition
+	Transition string
+	// TransitionFrom limits statuses tasks are transitioned from, empty means any status
+	TransitionFrom []string
+	// AddLabels and RemoveLabels are label edits applied to linked tasks
+	AddLabels    []string
+	RemoveLabels []string
 }
 
 // New creates Jira instance with all required details like email, Token, base url
-func New(config *Config) (Jira, error) {
+func New(ctx context.Context, config *Config) (Jira, error) {
 	j := Jira{
-		log:    config.Log,
-		dryRun: config.DryRun,
+		log:            config.Log,
+		dryRun:         config.DryRun,
+		fixVersionMode: config.FixVersionMode,
+		field:          config.Field,
+		notifyUsers:    !config.DisableNotifications,
+		transition:     config.Transition,
+		transitionFrom: config.TransitionFrom,
+		addLabels:      config.AddLabels,
+		removeLabels:   config.RemoveLabels,
+		retries:        &retryStats{},
+	}
+	if j.field == "" {
+		j.field = FieldFixVersions
+	}
+	if j.fixVersionMode == "" {
+		j.fixVersionMode = FixVersionModeAdd
 	}
 
 	// create retry client
 	retryClient := retryablehttp.NewClient()
 	retryClient.RetryMax = config.HTTPMaxRetries
 	retryClient.CheckRetry = j.retryPolicy
+	retryClient.Backoff = j.backoff
+	// keep last response when retries are exhausted, so Jira status and error messages aren't lost
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	retryClient.RetryWaitMin = retryMinWait
+	retryClient.RetryWaitMax = config.RetryMaxWait
+	if retryClient.RetryWaitMax <= 0 {
+		retryClient.RetryWaitMax = DefaultRetryMaxWait
+	}
+	retryClient.HTTPClient.Timeout = config.HTTPTimeout
 	// transform retryclient to http.Client
 	standardClient := retryClient.StandardClient()
 
@@ -73,20 +145,34 @@ func New(config *Config) (Jira, error) {
 
 	j.Client = client
 
-	_, err = j.getProject(config.ProjectID)
+	_, err = j.getProject(ctx, config.ProjectID)
 	if err != nil {
 		return j, err
 	}
 
+	if config.ValidateField && j.field != FieldFixVersions {
+		err = j.validateField(ctx)
+		if err != nil {
+			return j, err
+		}
+	}
+
+	if !j.notifyUsers {
+		err = j.checkNotifyUsersPermission(ctx)
+		if err != nil {
+			return j, err
+		}
+	}
+
 	return j, nil
 }
 
 // getProject tries to find provided Jira project
-func (j *Jira) getProject(projectID string) (jira.Project, error) {
+func (j *Jira) getProject(ctx context.Context, projectID string) (jira.Project, error) {
 	j.log.Debugf("[JIRA] getting project id from slug: %s", projectID)
-	p, _, err := j.Client.Project.Get(projectID)
+	p, res, err := j.Client.Project.GetWithContext(ctx, projectID)
 	if err != nil {
-		return jira.Project{}, err
+		return jira.Project{}, responseError(res, err)
 	}
 	j.log.Debugf("[JIRA] found project %s", p.Self)
 
@@ -98,20 +184,28 @@ func (j *Jira) getProject(projectID string) (jira.Project, error) {
 	return *p, nil
 }
 
-// GetVersion looks for given version name if exists
-func (j Jira) GetVersion(name string) (*jira.Version, bool, error) {
-	for i := range j.Project.Versions {
-		if j.Project.Versions[i].Name == name {
-			return &j.Project.Versions[i], true, nil
-		}
+// GetVersion looks for given version name if exists, it uses paginated version search and falls back to
+// versions embedded in project when the search is not available (older Jira Server)
+func (j Jira) GetVersion(ctx context.Context, name string) (*jira.Version, bool, error) {
+	version, isFound, err := j.searchVersion(ctx, name)
+	if errors.Is(err, ErrNotFound) {
+		j.log.Debugf("[JIRA] version search not available, using project versions (%s)", err)
+		version, isFound, err = findVersion(j.Project.Versions, name)
+	}
+	if err != nil {
+		return version, false, err
+	}
+	if !isFound {
+		j.log.Debugf("[JIRA] can't find version %s", name)
 	}
-	j.log.Debugf("[JIRA] can't find version %s", name)
-	return &jira.Version{}, false, nil
+
+	return version, isFound, nil
 }
 
-// CreateVersion creates version in Jira
-func (j *Jira) CreateVersion(name string) (*jira.Version, error) {
-	version, isFound, err := j.GetVersion(name)
+// CreateVersion creates version in Jira with given description, if the version is created in the meantime
+// by another run, the existing one is used
+func (j *Jira) CreateVersion(ctx context.Context, name, description string) (*jira.Version, error) {
+	version, isFound, err := j.GetVersion(ctx, name)
 	if err != nil {
 		return version, err
 	}
@@ -136,44 +230,112 @@ func (j *Jira) CreateVersion(name string) (*jira.Version, error) {
 		Released:    false,
 		StartDate:   time.Now().String(),
 		ReleaseDate: time.Now().String(),
-		// TODO: put task ids into description
-		Description: "",
+		Description: description,
 	}
 
-	if !j.dryRun {
-		v, _, err = j.Client.Version.Create(v)
-		if err != nil {
-			return v, err
+	if j.dryRun {
+		// version without ID can't be linked, so steps using it fail instead of pretending success
+		j.Version = v
+		j.log.Infof("[JIRA] dry run, version %s not created", v.Name)
+		return v, nil
+	}
+
+	v, res, err := j.Client.Version.CreateWithContext(ctx, v)
+	if err != nil {
+		err = responseError(res, err)
+		if errors.Is(err, ErrVersionConflict) {
+			return j.adoptExistingVersion(ctx, name, err)
 		}
+		return v, err
 	}
 
 	j.Version = v
 
 	j.log.Infof("[JIRA] version created %s", j.Version.Name)
 
-	return version, nil
+	return v, nil
+}
+
+// LinkTasksToVersion iterates over all give tasks and tries to link them to version,
+// tasks already linked to version are skipped
+func (j Jira) LinkTasksToVersion(ctx context.Context, taskIds []string) Report {
+	linked, err := j.GetVersionIssues(ctx)
+	if err != nil {
+		j.log.Warnf("[JIRA] can't check which tasks are already linked to %s, linking all of them (%s)", j.Version.Name, err)
+	}
+
+	return j.linkTasks(ctx, taskIds, linked)
 }
 
-// LinkTasksToVersion iterates over all give tasks and tries to link them to version
-func (j Jira) LinkTasksToVersion(taskIds []string) {
+// linkTasks links tasks to version skipping already linked ones, replace modes process all tasks
+// because already linked tasks may still have other versions to replace
+func (j Jira) linkTasks(ctx context.Context, taskIds, linked []string) Report {
+	report := Report{Version: j.Version.Name}
+	alreadyLinked := make(map[string]struct{}, len(linked))
+	if j.fixVersionMode == FixVersionModeAdd {
+		for _, taskID := range linked {
+			alreadyLinked[taskID] = struct{}{}
+		}
+	}
+
 	for _, taskID := range taskIds {
+		if _, ok := alreadyLinked[taskID]; ok {
+			j.log.Infof("[JIRA] task %s already linked to %s, skipping", taskID, j.Version.Name)
+			report.add(taskID, IssueStatusAlreadyLinked, nil)
+			continue
+		}
+
 		j.log.Debugf("[JIRA] linking %s to %s", taskID, j.Version.Name)
 
-		err := j.SetIssueVersion(taskID)
+		err := j.SetIssueVersion(ctx, taskID)
 		if err != nil {
-			j.log.Warnf("[JIRA] can't update task %s to fixed version %s (%s)", taskID, j.Version.Name, j.Version.ID)
+			j.log.Warnf("[JIRA] can't update task %s to version %s (%s) in %s", taskID, j.Version.Name, j.Version.ID, j.field)
+			report.add(taskID, IssueStatusFailed, err)
+			continue
 		}
+		report.add(taskID, IssueStatusLinked, nil)
 	}
+
+	return report
 }
 
 // SetIssueVersion makes http request to Jira service to update task with fixed version
-func (j Jira) SetIssueVersion(taskID string) error {
-	var res *jira.Response
+func (j Jira) SetIssueVersion(ctx context.Context, taskID string) error {
+	if j.Version.ID == "" {
+		return errors.Wrapf(ErrVersionNotCreated, "can't link task %s to %s", taskID, j.Version.Name)
+	}
+	operations, err := j.fixVersionOperations(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	p := UpdatePayload{
+		Update: UpdateTypePayload{
+			Field:    j.field,
+			Versions: operations,
+		},
+	}
+
+	j.log.Debugf("[JIRA] setting version %s in %s for task %s (%s)", j.Version.Name, j.field, taskID, j.fixVersionMode)
+	err = j.updateIssue(ctx, taskID, p)
+	if err != nil {
+		return err
+	}
+
+	j.log.Infof("[JIRA] task updated %s", taskID)
+	return nil
+}
+
+// RemoveIssueVersion makes http request to Jira service to remove version from task
+func (j Jira) RemoveIssueVersion(ctx context.Context, taskID string) error {
+	if j.Version.ID == "" {
+		return errors.Wrapf(ErrVersionNotCreated, "can't remove %s from task %s", j.Version.Name, taskID)
+	}
 	p := UpdatePayload{
 		Update: UpdateTypePayload{
-			FixVersions: []AddFixedVersion{
+			Field: j.field,
+			Versions: []VersionOperation{
 				{
-					Add: VersionID{
+					Remove: &VersionID{
 						ID: j.Version.ID,
 					},
 				},
@@ -181,28 +343,40 @@ func (j Jira) SetIssueVersion(taskID string) error {
 		},
 	}
 
-	j.log.Debugf("[JIRA] setting version %s for task %s", j.Version.Name, taskID)
-	req, err := j.Client.NewRequest("PUT", "/rest/api/2/issue/"+taskID, p)
+	j.log.Debugf("[JIRA] removing version %s in %s from task %s", j.Version.Name, j.field, taskID)
+	err := j.updateIssue(ctx, taskID, p)
+	if err != nil {
+		return err
+	}
+
+	j.log.Infof("[JIRA] version removed from task %s", taskID)
+	return nil
+}
+
+// updateIssue sends edit operations for given task
+func (j Jira) updateIssue(ctx context.Context, taskID string, p UpdatePayload) error {
+	url := "/rest/api/2/issue/" + taskID
+	if !j.notifyUsers {
+		url += "?notifyUsers=false"
+	}
+	req, err := j.Client.NewRequestWithContext(ctx, "PUT", url, p)
 	if err != nil {
-		return errors.Wrapf(err, "can't create Jira request to %s", "/rest/api/2/issue/"+taskID)
+		return errors.Wrapf(err, "can't create Jira request to %s", url)
 	}
 	req.Header.Add("Content-Type", "application/json;charset=UTF-8")
 
-	if !j.dryRun {
-		res, err = j.Client.Do(req, nil)
+	if j.dryRun {
+		return nil
 	}
 
+	res, err := j.Client.Do(req, nil)
 	if err != nil {
-		body, readErr := ioutil.ReadAll(res.Body)
-		if readErr != nil {
-			return readErr
-		}
+		err = responseError(res, err)
+		j.log.Warnf("[JIRA] error while updating task %s, %s", taskID, err)
 
-		j.log.Warnf("[JIRA] error while setting task %s to %s, %s", taskID, j.Version.Name, body)
-
-		return errors.Wrap(err, string(body))
+		return err
 	}
+	_ = res.Body.Close()
 
-	j.log.Infof("[JIRA] task updated %s", taskID)
 	return nil
 }
@@ -0,0 +1,314 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	projectResponse = `{"id": "10000", "key": "JR", "versions": [{"id": "10100", "name": "v1.0.0"}]}`
+	fieldsResponse  = `[
+		{"id": "fixVersions", "name": "Fix Version/s", "schema": {"type": "array", "items": "version", "system": "fixVersions"}},
+		{"id": "versions", "name": "Affects Version/s", "schema": {"type": "array", "items": "version", "system": "versions"}},
+		{"id": "customfield_10010", "name": "Release", "schema": {"type": "version", "custom": "version"}},
+		{"id": "customfield_10020", "name": "Deployed in", "schema": {"type": "array", "items": "version", "custom": "multiversion"}}
+	]`
+)
+
+// newTestServer starts Jira server answering project and field metadata requests, other requests go to given handlers,
+// field metadata handler can be replaced
+func newTestServer(t *testing.T, handlers map[string]http.HandlerFunc) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/project/10000", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, projectResponse)
+	})
+	if _, ok := handlers["/rest/api/2/field"]; !ok {
+		mux.HandleFunc("/rest/api/2/field", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, fieldsResponse)
+		})
+	}
+	for pattern, handler := range handlers {
+		mux.HandleFunc(pattern, handler)
+	}
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newTestJira creates Jira connected to given test server with version v1.0.0 selected
+func newTestJira(t *testing.T, server *httptest.Server) Jira {
+	log := zap.NewExample().Sugar()
+	t.Cleanup(func() {
+		_ = log.Sync()
+	})
+
+	j, err := New(context.Background(), &Config{
+		ProjectID: "10000",
+		BaseURL:   server.URL,
+		Log:       log,
+	})
+	require.NoError(t, err)
+	j.Version = &jira.Version{ID: "10100", Name: "v1.0.0"}
+
+	return j
+}
+
+// dropConnection closes connection without writing any response
+func dropConnection(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hijacker, ok := w.(http.Hijacker)
+		require.True(t, ok)
+		conn, _, err := hijacker.Hijack()
+		require.NoError(t, err)
+		_ = conn.Close()
+	}
+}
+
+func TestJira_SetIssueVersion_TransportError(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/issue/JR-1": dropConnection(t),
+	})
+	j := newTestJira(t, server)
+
+	err := j.SetIssueVersion(context.Background(), "JR-1")
+
+	var transportErr *TransportError
+	assert.True(t, errors.As(err, &transportErr), "expected TransportError, got %v", err)
+}
+
+func TestJira_SetIssueVersion_ConnectionRefused(t *testing.T) {
+	server := newTestServer(t, nil)
+	j := newTestJira(t, server)
+	server.Close()
+
+	err := j.SetIssueVersion(context.Background(), "JR-1")
+
+	var transportErr *TransportError
+	assert.True(t, errors.As(err, &transportErr), "expected TransportError, got %v", err)
+}
+
+func TestJira_SetIssueVersion_HTTPError(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/issue/JR-1": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"errorMessages": [], "errors": {"fixVersions": "Version id '10100' is not valid"}}`)
+		},
+	})
+	j := newTestJira(t, server)
+
+	err := j.SetIssueVersion(context.Background(), "JR-1")
+
+	var httpErr *HTTPError
+	require.True(t, errors.As(err, &httpErr), "expected HTTPError, got %v", err)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+	assert.Contains(t, string(httpErr.Body), "is not valid")
+}
+
+func TestJira_SetIssueVersion_Success(t *testing.T) {
+	var body string
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/issue/JR-1": func(w http.ResponseWriter, r *http.Request) {
+			buf := make([]byte, r.ContentLength)
+			_, _ = r.Body.Read(buf)
+			body = string(buf)
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	j := newTestJira(t, server)
+
+	err := j.SetIssueVersion(context.Background(), "JR-1")
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"update": {"fixVersions": [{"add": {"id": "10100"}}]}}`, body)
+}
+
+func TestJira_SetIssueVersion_RetriesExhausted(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		target  error
+		message string
+	}{
+		{
+			name:    "should keep rate limit response",
+			status:  http.StatusTooManyRequests,
+			target:  ErrRateLimited,
+			message: "Rate limit exceeded.",
+		},
+		{
+			name:    "should keep server error response",
+			status:  http.StatusServiceUnavailable,
+			message: "Jira is down for maintenance.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := newTestServer(t, map[string]http.HandlerFunc{
+				"/rest/api/2/issue/JR-1": func(w http.ResponseWriter, r *http.Request) {
+					attempts++
+					w.Header().Set("Content-Type", "application/json")
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tt.status)
+					_, _ = fmt.Fprintf(w, `{"errorMessages": [%q], "errors": {}}`, tt.message)
+				},
+			})
+			j, err := New(context.Background(), &Config{
+				ProjectID:      "10000",
+				BaseURL:        server.URL,
+				Log:            zap.NewNop().Sugar(),
+				HTTPMaxRetries: 2,
+				RetryMaxWait:   time.Millisecond,
+			})
+			require.NoError(t, err)
+			j.Version = &jira.Version{ID: "10100", Name: "v1.0.0"}
+
+			err = j.SetIssueVersion(context.Background(), "JR-1")
+
+			var httpErr *HTTPError
+			require.True(t, errors.As(err, &httpErr), "expected HTTPError, got %v", err)
+			assert.Equal(t, 3, attempts)
+			assert.Equal(t, tt.status, httpErr.StatusCode)
+			assert.Equal(t, []string{tt.message}, httpErr.Messages)
+			if tt.target != nil {
+				assert.True(t, errors.Is(err, tt.target), "expected %v, got %v", tt.target, err)
+			}
+		})
+	}
+}
+
+func TestJira_retryPolicy_NilResponse(t *testing.T) {
+	log := zap.NewExample().Sugar()
+	j := &Jira{log: log}
+
+	assert.NotPanics(t, func() {
+		shouldRetry, _ := j.retryPolicy(context.Background(), nil, errors.New("connection refused"))
+		assert.True(t, shouldRetry)
+	})
+}
+
+func TestJira_SetIssueVersion_TypedErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		target  error
+		message string
+	}{
+		{
+			name:    "should detect missing issue",
+			status:  http.StatusNotFound,
+			body:    `{"errorMessages": ["Issue does not exist or you do not have permission to see it."], "errors": {}}`,
+			target:  ErrNotFound,
+			message: "Issue does not exist",
+		},
+		{
+			name:    "should detect missing permission",
+			status:  http.StatusForbidden,
+			body:    `{"errorMessages": ["You do not have permission to edit issues in this project."]}`,
+			target:  ErrForbidden,
+			message: "permission to edit issues",
+		},
+		{
+			name:    "should detect rejected credentials",
+			status:  http.StatusUnauthorized,
+			body:    `Unauthorized`,
+			target:  ErrUnauthorized,
+			message: "Unauthorized",
+		},
+		{
+			name:    "should parse field errors",
+			status:  http.StatusBadRequest,
+			body:    `{"errorMessages": [], "errors": {"name": "A version with this name already exists in this project."}}`,
+			target:  ErrVersionConflict,
+			message: "name: A version with this name already exists",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, map[string]http.HandlerFunc{
+				"/rest/api/2/issue/JR-1": func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tt.status)
+					_, _ = fmt.Fprint(w, tt.body)
+				},
+			})
+			j := newTestJira(t, server)
+
+			err := j.SetIssueVersion(context.Background(), "JR-1")
+
+			assert.True(t, errors.Is(err, tt.target), "expected %v, got %v", tt.target, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestJira_LinkTasksToVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          FixVersionMode
+		search        http.HandlerFunc
+		wantUpdated   []string
+		alreadyLinked []string
+	}{
+		{
+			name:          "should skip already linked tasks in add mode",
+			mode:          FixVersionModeAdd,
+			search:        linkedIssues("JR-1"),
+			wantUpdated:   []string{"/rest/api/2/issue/JR-2"},
+			alreadyLinked: []string{"JR-1"},
+		},
+		{
+			name:        "should update already linked tasks again in replace mode",
+			mode:        FixVersionModeReplace,
+			search:      linkedIssues("JR-1"),
+			wantUpdated: []string{"/rest/api/2/issue/JR-1", "/rest/api/2/issue/JR-2"},
+		},
+		{
+			name: "should link all tasks when linked tasks can't be found",
+			mode: FixVersionModeAdd,
+			search: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprint(w, `{"errorMessages": ["Field 'fixVersion' does not exist."], "errors": {}}`)
+			},
+			wantUpdated: []string{"/rest/api/2/issue/JR-1", "/rest/api/2/issue/JR-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated []string
+			server := newTestServer(t, map[string]http.HandlerFunc{
+				"/rest/api/2/search": tt.search,
+				"/rest/api/2/issue/": func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodPut, r.Method)
+					updated = append(updated, r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				},
+			})
+			j := newTestJira(t, server)
+			j.fixVersionMode = tt.mode
+
+			report := j.LinkTasksToVersion(context.Background(), []string{"JR-1", "JR-2"})
+
+			assert.Equal(t, tt.wantUpdated, updated)
+			assert.Equal(t, tt.alreadyLinked, report.Keys(IssueStatusAlreadyLinked))
+			assert.False(t, report.HasFailures())
+		})
+	}
+}
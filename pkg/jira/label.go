@@ -0,0 +1,95 @@
+package jira
+
+import (
+	"context"
+	"strings"
+)
+
+// LabelStatus is result of editing labels of single task
+type LabelStatus string
+
+const (
+	// LabelStatusDone means labels were edited
+	LabelStatusDone LabelStatus = "labeled"
+	// LabelStatusFailed means labels couldn't be edited
+	LabelStatusFailed LabelStatus = "failed"
+)
+
+// LabelOperation is single edit operation on labels field, only one of them should be set
+type LabelOperation struct {
+	Add    string `json:"add,omitempty"`
+	Remove string `json:"remove,omitempty"`
+}
+
+// HasLabelEdits checks if any label should be added or removed
+func (j Jira) HasLabelEdits() bool {
+	return len(j.addLabels) > 0 || len(j.removeLabels) > 0
+}
+
+// LabelLinkedTasks applies configured label edits to tasks linked to version in single request per task
+func (j Jira) LabelLinkedTasks(ctx context.Context, report Report) Report {
+	operations := j.labelOperations()
+	if len(operations) == 0 {
+		return report
+	}
+
+	for i := range report.Issues {
+		issue := &report.Issues[i]
+		if issue.Status != IssueStatusLinked && issue.Status != IssueStatusAlreadyLinked {
+			continue
+		}
+
+		issue.Labels, issue.LabelsErr = j.labelTask(ctx, issue.Key, operations)
+		if issue.LabelsErr != nil {
+			j.log.Warnf("[JIRA] can't edit labels of task %s (%s)", issue.Key, issue.LabelsErr)
+		}
+	}
+
+	return report
+}
+
+// labelTask sends label edits of single task
+func (j Jira) labelTask(ctx context.Context, taskID string, operations []LabelOperation) (LabelStatus, error) {
+	p := UpdatePayload{
+		Update: UpdateTypePayload{
+			Labels: operations,
+		},
+	}
+
+	// label edits are reported by dry run plan, so nothing is logged here in dry run
+	err := j.updateIssue(ctx, taskID, p)
+	if err != nil {
+		return LabelStatusFailed, err
+	}
+	if !j.dryRun {
+		j.log.Infof("[JIRA] labels of task %s updated", taskID)
+	}
+
+	return LabelStatusDone, nil
+}
+
+// labelOperations builds labels edit operations from configured labels
+func (j Jira) labelOperations() []LabelOperation {
+	operations := make([]LabelOperation, 0, len(j.addLabels)+len(j.removeLabels))
+	for _, label := range j.addLabels {
+		operations = append(operations, LabelOperation{Add: label})
+	}
+	for _, label := range j.removeLabels {
+		operations = append(operations, LabelOperation{Remove: label})
+	}
+	return operations
+}
+
+// describeLabelOperations prints operations like `+deployed-prod -deployed-staging`, it's used in dry run plan
+func describeLabelOperations(operations []LabelOperation) string {
+	parts := make([]string, 0, len(operations))
+	for _, o := range operations {
+		if o.Add != "" {
+			parts = append(parts, "+"+o.Add)
+		}
+		if o.Remove != "" {
+			parts = append(parts, "-"+o.Remove)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,37 @@
+package jira
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJira_LabelLinkedTasks(t *testing.T) {
+	var updates []string
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/issue/": func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			updates = append(updates, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	j := newTestJira(t, server)
+	j.addLabels = []string{"deployed-prod"}
+	j.removeLabels = []string{"deployed-staging"}
+	report := Report{Version: "v1.0.0"}
+	report.add("JR-1", IssueStatusLinked, nil)
+	report.add("JR-2", IssueStatusFailed, assert.AnError)
+
+	report = j.LabelLinkedTasks(context.Background(), report)
+
+	assert.Equal(t, []string{"JR-1"}, report.Keys(LabelStatusDone))
+	assert.Equal(t, []string{
+		`PUT /rest/api/2/issue/JR-1 {"update":{"labels":[{"add":"deployed-prod"},{"remove":"deployed-staging"}]}}`,
+	}, updates)
+}
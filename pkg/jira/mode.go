@@ -0,0 +1,61 @@
+package jira
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// FixVersionMode defines how version is put into issue version field
+type FixVersionMode string
+
+const (
+	// FixVersionModeAdd adds version next to existing versions
+	FixVersionModeAdd FixVersionMode = "add"
+	// FixVersionModeReplace replaces all versions with the version
+	FixVersionModeReplace FixVersionMode = "replace"
+	// FixVersionModeReplaceUnreleased replaces only unreleased versions, released ones are kept
+	FixVersionModeReplaceUnreleased FixVersionMode = "replace-unreleased"
+)
+
+// ParseFixVersionMode validates fixVersion mode name
+func ParseFixVersionMode(name string) (FixVersionMode, error) {
+	switch m := FixVersionMode(name); m {
+	case FixVersionModeAdd, FixVersionModeReplace, FixVersionModeReplaceUnreleased:
+		return m, nil
+	case "":
+		return FixVersionModeAdd, nil
+	default:
+		return "", errors.Errorf(
+			"unknown fix version mode %s, expected %s, %s or %s",
+			name, FixVersionModeAdd, FixVersionModeReplace, FixVersionModeReplaceUnreleased,
+		)
+	}
+}
+
+// fixVersionOperations builds version field edit operations linking task to version according to configured mode
+func (j Jira) fixVersionOperations(ctx context.Context, taskID string) ([]VersionOperation, error) {
+	version := VersionID{ID: j.Version.ID}
+
+	switch j.fixVersionMode {
+	case FixVersionModeReplace:
+		return []VersionOperation{{Set: []VersionID{version}}}, nil
+	case FixVersionModeReplaceUnreleased:
+		current, err := j.getIssueVersions(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+
+		versions := make([]VersionID, 0, len(current)+1)
+		for _, v := range current {
+			if v.ID != version.ID && v.Released != nil && *v.Released {
+				versions = append(versions, VersionID{ID: v.ID})
+			}
+		}
+		versions = append(versions, version)
+
+		return []VersionOperation{{Set: versions}}, nil
+	default:
+		return []VersionOperation{{Add: &version}}, nil
+	}
+}
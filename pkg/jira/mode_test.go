@@ -0,0 +1,77 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJira_SetIssueVersion_Modes(t *testing.T) {
+	// JR-1 has released v0.9.0, unreleased v1.1.0 and the version itself
+	const issueResponse = `{"key": "JR-1", "fields": {"fixVersions": [
+		{"id": "10090", "name": "v0.9.0", "released": true},
+		{"id": "10110", "name": "v1.1.0", "released": false},
+		{"id": "10100", "name": "v1.0.0", "released": false}
+	]}}`
+
+	tests := []struct {
+		name     string
+		mode     FixVersionMode
+		requests []string
+	}{
+		{
+			name: "should add version",
+			mode: FixVersionModeAdd,
+			requests: []string{
+				`PUT /rest/api/2/issue/JR-1 {"update":{"fixVersions":[{"add":{"id":"10100"}}]}}`,
+			},
+		},
+		{
+			name: "should replace all versions",
+			mode: FixVersionModeReplace,
+			requests: []string{
+				`PUT /rest/api/2/issue/JR-1 {"update":{"fixVersions":[{"set":[{"id":"10100"}]}]}}`,
+			},
+		},
+		{
+			name: "should replace unreleased versions keeping released ones",
+			mode: FixVersionModeReplaceUnreleased,
+			requests: []string{
+				`GET /rest/api/2/issue/JR-1 fields=fixVersions`,
+				`PUT /rest/api/2/issue/JR-1 {"update":{"fixVersions":[{"set":[{"id":"10090"},{"id":"10100"}]}]}}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			server := newTestServer(t, map[string]http.HandlerFunc{
+				"/rest/api/2/issue/JR-1": func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == http.MethodGet {
+						requests = append(requests, r.Method+" "+r.URL.Path+" "+r.URL.RawQuery)
+						w.Header().Set("Content-Type", "application/json")
+						_, _ = fmt.Fprint(w, issueResponse)
+						return
+					}
+					body, err := ioutil.ReadAll(r.Body)
+					require.NoError(t, err)
+					requests = append(requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+					w.WriteHeader(http.StatusNoContent)
+				},
+			})
+			j := newTestJira(t, server)
+			j.fixVersionMode = tt.mode
+
+			err := j.SetIssueVersion(context.Background(), "JR-1")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.requests, requests)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package jira
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultVersionTemplate names version after the tag
+const DefaultVersionTemplate = "{{.Tag}}"
+
+// MaxVersionNameLength is the longest version name accepted by Jira
+const MaxVersionNameLength = 255
+
+// semverRe matches semantic version at the end of a tag, e.g. v2.1.0, web-2.1.0-rc.1 or 2.1.0+build.5
+var semverRe = regexp.MustCompile(`v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+
+// VersionNameData is available in version name template
+type VersionNameData struct {
+	Tag string
+	// Major, Minor, Patch, Prerelease and Build are parts of semantic version found in the tag, empty if there is none
+	Major      string
+	Minor      string
+	Patch      string
+	Prerelease string
+	Build      string
+	// Project is Jira project key
+	Project string
+	Date    time.Time
+	// Branch is currently checked out git branch, empty if HEAD is detached
+	Branch string
+}
+
+// NewVersionNameData creates version name template data with semantic version parts parsed from the tag
+func NewVersionNameData(tag, project, branch string, date time.Time) VersionNameData {
+	data := VersionNameData{
+		Tag:     tag,
+		Project: project,
+		Date:    date,
+		Branch:  branch,
+	}
+
+	parts := semverRe.FindStringSubmatch(tag)
+	if parts != nil {
+		data.Major, data.Minor, data.Patch, data.Prerelease, data.Build = parts[1], parts[2], parts[3], parts[4], parts[5]
+	}
+
+	return data
+}
+
+// ParseVersionTemplate parses version name template, DefaultVersionTemplate is used if text is empty
+func ParseVersionTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultVersionTemplate
+	}
+
+	t, err := template.New("version").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't parse version template")
+	}
+
+	return t, nil
+}
+
+// VersionName renders version name from template and checks if Jira accepts it
+func VersionName(t *template.Template, data VersionNameData) (string, error) {
+	var buf bytes.Buffer
+	err := t.Execute(&buf, data)
+	if err != nil {
+		return "", errors.Wrap(err, "can't render version template")
+	}
+
+	name := strings.TrimSpace(buf.String())
+	err = ValidateVersionName(name)
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// ValidateVersionName checks if version name is not empty and fits Jira length limit
+func ValidateVersionName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("version name is empty")
+	}
+	if length := utf8.RuneCountInString(name); length > MaxVersionNameLength {
+		return errors.Errorf("version name has %d characters, Jira allows at most %d", length, MaxVersionNameLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionName(t *testing.T) {
+	date := time.Date(2021, time.April, 3, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		template string
+		tag      string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "should use tag by default",
+			tag:  "v2.1.0",
+			want: "v2.1.0",
+		},
+		{
+			name:     "should use semver parts",
+			template: "web-{{.Major}}.{{.Minor}}.{{.Patch}}",
+			tag:      "v2.1.0",
+			want:     "web-2.1.0",
+		},
+		{
+			name:     "should use prerelease and build",
+			template: "{{.Patch}} {{.Prerelease}} {{.Build}}",
+			tag:      "web-v2.1.3-rc.1+build.5",
+			want:     "3 rc.1 build.5",
+		},
+		{
+			name:     "should use date, project and branch",
+			template: `Release {{.Date.Format "2006.01"}}.{{.Patch}} {{.Project}} {{.Branch}}`,
+			tag:      "v1.0.3",
+			want:     "Release 2021.04.3 JR main",
+		},
+		{
+			name:     "should leave semver parts empty for other tags",
+			template: "{{.Tag}}{{.Major}}",
+			tag:      "release-42",
+			want:     "release-42",
+		},
+		{
+			name:     "should reject empty name",
+			template: "{{.Prerelease}}",
+			tag:      "v1.0.0",
+			wantErr:  true,
+		},
+		{
+			name:     "should reject too long name",
+			template: strings.Repeat("x", MaxVersionNameLength) + "{{.Tag}}",
+			tag:      "v1.0.0",
+			wantErr:  true,
+		},
+		{
+			name:     "should reject unknown field",
+			template: "{{.Unknown}}",
+			tag:      "v1.0.0",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseVersionTemplate(tt.template)
+			require.NoError(t, err)
+
+			got, err := VersionName(tmpl, NewVersionNameData(tt.tag, "JR", "main", date))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
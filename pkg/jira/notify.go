@@ -0,0 +1,46 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotifyUsersPermission is returned when notifications can't be disabled because of missing admin permission
+var ErrNotifyUsersPermission = errors.New(
+	"disabling notifications requires Administer Jira or Administer Projects permission, run with --notify-users=true or grant the permission",
+)
+
+// adminPermissions allow to disable notifications on issue edit
+var adminPermissions = []string{"ADMINISTER", "ADMINISTER_PROJECTS"}
+
+type myPermissions struct {
+	Permissions map[string]struct {
+		HavePermission bool `json:"havePermission"`
+	} `json:"permissions"`
+}
+
+// checkNotifyUsersPermission makes sure account is allowed to edit issues without sending notifications
+func (j Jira) checkNotifyUsersPermission(ctx context.Context) error {
+	var p myPermissions
+
+	url := fmt.Sprintf("/rest/api/2/mypermissions?projectId=%s&permissions=%s,%s", j.ProjectID, adminPermissions[0], adminPermissions[1])
+	req, err := j.Client.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return errors.Wrapf(err, "can't create Jira request to %s", url)
+	}
+	res, err := j.Client.Do(req, &p)
+	if err != nil {
+		return errors.Wrap(responseError(res, err), "can't check permissions")
+	}
+
+	for _, name := range adminPermissions {
+		if p.Permissions[name].HavePermission {
+			j.log.Debugf("[JIRA] account has %s permission, notifications can be disabled", name)
+			return nil
+		}
+	}
+
+	return ErrNotifyUsersPermission
+}
@@ -0,0 +1,96 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// permissionsServer serves project, field metadata and permissions with given admin permissions
+func permissionsServer(t *testing.T, administer, administerProjects bool, handlers map[string]http.HandlerFunc) string {
+	if handlers == nil {
+		handlers = map[string]http.HandlerFunc{}
+	}
+	handlers["/rest/api/2/mypermissions"] = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "10000", r.URL.Query().Get("projectId"))
+		assert.Equal(t, "ADMINISTER,ADMINISTER_PROJECTS", r.URL.Query().Get("permissions"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"permissions": {
+			"ADMINISTER": {"havePermission": %t},
+			"ADMINISTER_PROJECTS": {"havePermission": %t}
+		}}`, administer, administerProjects)
+	}
+
+	return newTestServer(t, handlers).URL
+}
+
+func TestNew_NotifyUsersPermission(t *testing.T) {
+	tests := []struct {
+		name               string
+		administer         bool
+		administerProjects bool
+		wantErr            error
+	}{
+		{
+			name:    "should reject account without admin permissions",
+			wantErr: ErrNotifyUsersPermission,
+		},
+		{
+			name:               "should accept project admin",
+			administerProjects: true,
+		},
+		{
+			name:       "should accept Jira admin",
+			administer: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL := permissionsServer(t, tt.administer, tt.administerProjects, nil)
+
+			_, err := New(context.Background(), &Config{
+				ProjectID:            "10000",
+				BaseURL:              baseURL,
+				Log:                  zap.NewNop().Sugar(),
+				DisableNotifications: true,
+			})
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "expected %v, got %v", tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestJira_SetIssueVersion_NotifyUsersDisabled(t *testing.T) {
+	var query string
+	baseURL := permissionsServer(t, false, true, map[string]http.HandlerFunc{
+		"/rest/api/2/issue/JR-1": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			query = r.URL.RawQuery
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	j, err := New(context.Background(), &Config{
+		ProjectID:            "10000",
+		BaseURL:              baseURL,
+		Log:                  zap.NewNop().Sugar(),
+		DisableNotifications: true,
+	})
+	require.NoError(t, err)
+	j.Version = &jira.Version{ID: "10100", Name: "v1.0.0"}
+
+	err = j.SetIssueVersion(context.Background(), "JR-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "notifyUsers=false", query)
+}
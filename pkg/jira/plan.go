@@ -0,0 +1,236 @@
+package jira
+
+import (
+	"context"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pkg/errors"
+)
+
+// Plan lists changes a run would make, it's built with read-only requests only
+type Plan struct {
+	Version string
+	// CreateVersion is true when version doesn't exist yet and would be created
+	CreateVersion bool
+	// Link are tasks which would be linked to version
+	Link []string
+	// AlreadyLinked are tasks already having the version, they would be skipped in add mode
+	AlreadyLinked []string
+	// NotFound are tasks which don't exist in Jira or account can't see them
+	NotFound []string
+	// Unlink are tasks version would be removed from in sync mode
+	Unlink []string
+	// Label are linked tasks which labels would be edited with LabelEdits, e.g. `+deployed-prod -deployed-staging`
+	Label      []string
+	LabelEdits string
+	// Release has transitions and comments of linked tasks checked in dry run, empty when they aren't planned
+	Release Report
+}
+
+// Lines formats plan as one change per line, e.g. "link JR-1", so plans of two runs can be diffed
+func (p Plan) Lines() []string {
+	versionAction := "reuse-version"
+	if p.CreateVersion {
+		versionAction = "create-version"
+	}
+	lines := []string{versionAction + " " + p.Version}
+
+	groups := []struct {
+		action string
+		keys   []string
+	}{
+		{"link", p.Link},
+		{"already-linked", p.AlreadyLinked},
+		{"not-found", p.NotFound},
+		{"unlink", p.Unlink},
+	}
+	for _, group := range groups {
+		for _, key := range group.keys {
+			lines = append(lines, group.action+" "+key)
+		}
+	}
+	for _, key := range p.Label {
+		lines = append(lines, "label "+key+" "+p.LabelEdits)
+	}
+
+	return append(lines, releaseLines(p.Release)...)
+}
+
+// LinkedReport reports tasks which would be linked or are linked already, so release steps can be checked for them
+func (p Plan) LinkedReport() Report {
+	report := Report{Version: p.Version}
+	for _, key := range p.Link {
+		report.add(key, IssueStatusLinked, nil)
+	}
+	for _, key := range p.AlreadyLinked {
+		report.add(key, IssueStatusAlreadyLinked, nil)
+	}
+
+	return report
+}
+
+// ReleasePlan lists changes release would make, transitions and comments are taken from report of dry run
+type ReleasePlan struct {
+	Version string
+	// AlreadyReleased is true when version is released already, so it would be left as it is
+	AlreadyReleased bool
+	Report          Report
+}
+
+// Lines formats release plan as one change per line, e.g. "transition JR-1"
+func (p ReleasePlan) Lines() []string {
+	versionAction := "release-version"
+	if p.AlreadyReleased {
+		versionAction = "already-released"
+	}
+
+	return append([]string{versionAction + " " + p.Version}, releaseLines(p.Report)...)
+}
+
+// releaseLines formats transitions and comments checked in dry run
+func releaseLines(report Report) []string {
+	groups := []struct {
+		action string
+		status Status
+	}{
+		{"transition", TransitionStatusDone},
+		{"transition-skipped", TransitionStatusSkipped},
+		{"transition-unavailable", TransitionStatusUnavailable},
+		{"transition-failed", TransitionStatusFailed},
+		{"comment", CommentStatusDone},
+		{"already-commented", CommentStatusAlreadyDone},
+		{"comment-failed", CommentStatusFailed},
+	}
+
+	var lines []string
+	for _, group := range groups {
+		for _, key := range report.Keys(group.status) {
+			lines = append(lines, group.action+" "+key)
+		}
+	}
+
+	return lines
+}
+
+// PlanRelease builds release plan of selected version, report should come from transitions and comments in dry run
+func (j Jira) PlanRelease(report Report) ReleasePlan {
+	return ReleasePlan{
+		Version:         j.Version.Name,
+		AlreadyReleased: j.Version.Released,
+		Report:          report,
+	}
+}
+
+// RenamePlan is version rename checked in dry run
+type RenamePlan struct {
+	Version string
+	NewName string
+}
+
+// Lines formats rename plan as single line
+func (p RenamePlan) Lines() []string {
+	return []string{"rename-version " + p.Version + " " + p.NewName}
+}
+
+// MergePlan lists changes version merge would make, moved issues are taken from report of dry run
+type MergePlan struct {
+	Source string
+	Report Report
+}
+
+// Lines formats merge plan as one change per line, e.g. "move JR-1", source version is deleted last
+func (p MergePlan) Lines() []string {
+	var lines []string
+	for _, key := range p.Report.Keys(IssueStatusLinked) {
+		lines = append(lines, "move "+key)
+	}
+
+	return append(lines, "delete-version "+p.Source)
+}
+
+// PlanVersion checks if version with given name would be created or reused
+func (j Jira) PlanVersion(ctx context.Context, name string) (Plan, error) {
+	plan := Plan{Version: name}
+
+	version, isFound, err := j.GetVersion(ctx, name)
+	if err != nil {
+		return plan, err
+	}
+	plan.CreateVersion = !isFound
+	if isFound {
+		j.log.Debugf("[JIRA] version %s exists (%s)", version.Name, version.ID)
+	}
+
+	return plan, nil
+}
+
+// PlanLink adds tasks to plan, it checks which of them exist and which are already linked to version,
+// with sync version would be also removed from linked tasks missing in given tasks. Planned version is selected,
+// so following release steps can be checked in dry run.
+func (j *Jira) PlanLink(ctx context.Context, plan Plan, taskIds []string, sync bool) (Plan, error) {
+	var linked []string
+	if plan.CreateVersion {
+		j.Version = &jira.Version{Name: plan.Version}
+	} else {
+		version, _, err := j.GetVersion(ctx, plan.Version)
+		if err != nil {
+			return plan, err
+		}
+		j.Version = version
+		linked, err = j.GetVersionIssues(ctx)
+		if err != nil {
+			return plan, err
+		}
+	}
+
+	isLinked := make(map[string]struct{}, len(linked))
+	for _, taskID := range linked {
+		isLinked[taskID] = struct{}{}
+	}
+	for _, taskID := range taskIds {
+		// like linkTasks, replace modes link already linked tasks again, because they may have other versions to replace
+		if _, ok := isLinked[taskID]; ok {
+			if j.fixVersionMode == FixVersionModeAdd {
+				plan.AlreadyLinked = append(plan.AlreadyLinked, taskID)
+			} else {
+				plan.Link = append(plan.Link, taskID)
+			}
+			continue
+		}
+
+		exists, err := j.issueExists(ctx, taskID)
+		if err != nil {
+			return plan, err
+		}
+		if !exists {
+			plan.NotFound = append(plan.NotFound, taskID)
+			continue
+		}
+		plan.Link = append(plan.Link, taskID)
+	}
+
+	if sync {
+		plan.Unlink = difference(linked, taskIds)
+	}
+	if j.HasLabelEdits() {
+		plan.Label = append(append([]string(nil), plan.Link...), plan.AlreadyLinked...)
+		plan.LabelEdits = describeLabelOperations(j.labelOperations())
+	}
+
+	return plan, nil
+}
+
+// issueExists checks if issue exists and account can see it
+func (j Jira) issueExists(ctx context.Context, taskID string) (bool, error) {
+	_, res, err := j.Client.Issue.GetWithContext(ctx, taskID, &jira.GetQueryOptions{Fields: "key"})
+	if err == nil {
+		return true, nil
+	}
+
+	err = responseError(res, err)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+
+	return false, errors.Wrapf(err, "can't get task %s", taskID)
+}
@@ -0,0 +1,210 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPlan_Lines(t *testing.T) {
+	plan := Plan{
+		Version:       "v1.1.0",
+		CreateVersion: true,
+		Link:          []string{"JR-2", "JR-1"},
+		AlreadyLinked: []string{"JR-3"},
+		NotFound:      []string{"JR-9"},
+		Unlink:        []string{"JR-5"},
+		Label:         []string{"JR-2", "JR-1", "JR-3"},
+		LabelEdits:    "+deployed-prod -deployed-staging",
+		Release: Report{Issues: []IssueResult{
+			{Key: "JR-2", Status: IssueStatusLinked, Transition: TransitionStatusDone, Comment: CommentStatusDone},
+			{Key: "JR-3", Status: IssueStatusAlreadyLinked, Transition: TransitionStatusSkipped, Comment: CommentStatusAlreadyDone},
+		}},
+	}
+
+	assert.Equal(t, []string{
+		"create-version v1.1.0",
+		"link JR-2",
+		"link JR-1",
+		"already-linked JR-3",
+		"not-found JR-9",
+		"unlink JR-5",
+		"label JR-2 +deployed-prod -deployed-staging",
+		"label JR-1 +deployed-prod -deployed-staging",
+		"label JR-3 +deployed-prod -deployed-staging",
+		"transition JR-2",
+		"transition-skipped JR-3",
+		"comment JR-2",
+		"already-commented JR-3",
+	}, plan.Lines())
+	assert.Equal(t, []string{"reuse-version v1.0.0"}, Plan{Version: "v1.0.0"}.Lines())
+}
+
+func TestJira_PlanLink(t *testing.T) {
+	tests := []struct {
+		name string
+		mode FixVersionMode
+		want []string
+	}{
+		{
+			name: "should skip already linked tasks in add mode",
+			mode: FixVersionModeAdd,
+			want: []string{
+				"reuse-version v1.0.0",
+				"link JR-2",
+				"already-linked JR-1",
+				"not-found JR-9",
+				"unlink JR-5",
+			},
+		},
+		{
+			name: "should link already linked tasks again in replace mode",
+			mode: FixVersionModeReplace,
+			want: []string{
+				"reuse-version v1.0.0",
+				"link JR-1",
+				"link JR-2",
+				"not-found JR-9",
+				"unlink JR-5",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			issue := func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					writes = append(writes, r.Method+" "+r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprint(w, `{"id": "1", "key": "JR-2"}`)
+			}
+			server := newTestServer(t, map[string]http.HandlerFunc{
+				"/rest/api/2/search": func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = fmt.Fprint(w, `{"startAt": 0, "maxResults": 50, "total": 2, "issues": [{"key": "JR-1"}, {"key": "JR-5"}]}`)
+				},
+				"/rest/api/2/issue/JR-2": issue,
+				"/rest/api/2/issue/JR-9": func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = fmt.Fprint(w, `{"errorMessages": ["Issue does not exist or you do not have permission to see it."], "errors": {}}`)
+				},
+			})
+			j := newTestJira(t, server)
+			j.fixVersionMode = tt.mode
+
+			plan, err := j.PlanVersion(context.Background(), "v1.0.0")
+			require.NoError(t, err)
+			plan, err = j.PlanLink(context.Background(), plan, []string{"JR-1", "JR-2", "JR-9"}, true)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, plan.Lines())
+			assert.Empty(t, writes)
+		})
+	}
+}
+
+func TestJira_PlanLink_LabelsAndRelease(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/project/10000/version": versionSearch(),
+		"/rest/api/2/issue/JR-2": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"key": "JR-2", "fields": {"status": {"name": "In Progress"}}}`)
+		},
+		"/rest/api/2/issue/JR-2/transitions": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"transitions": [{"id": "31", "name": "Released", "to": {"name": "Done"}}]}`)
+		},
+	})
+	j := newTestJira(t, server)
+	j.dryRun = true
+	j.addLabels = []string{"deployed-prod"}
+	j.removeLabels = []string{"deployed-staging"}
+	j.transition = "Released"
+
+	plan, err := j.PlanVersion(context.Background(), "v1.1.0")
+	require.NoError(t, err)
+	plan, err = j.PlanLink(context.Background(), plan, []string{"JR-2"}, false)
+	require.NoError(t, err)
+	plan.Release = j.TransitionLinkedTasks(context.Background(), plan.LinkedReport())
+
+	assert.Equal(t, "v1.1.0", j.Version.Name)
+	assert.Equal(t, []string{
+		"create-version v1.1.0",
+		"link JR-2",
+		"label JR-2 +deployed-prod -deployed-staging",
+		"transition JR-2",
+	}, plan.Lines())
+}
+
+func TestJira_CreateVersion_DryRun(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/version": func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected %s %s in dry run", r.Method, r.URL.Path)
+		},
+	})
+	log := zap.NewExample().Sugar()
+	j, err := New(context.Background(), &Config{ProjectID: "10000", BaseURL: server.URL, Log: log, DryRun: true})
+	require.NoError(t, err)
+
+	version, err := j.CreateVersion(context.Background(), "v1.1.0", "")
+	require.NoError(t, err)
+	assert.Empty(t, version.ID)
+
+	err = j.SetIssueVersion(context.Background(), "JR-1")
+	assert.True(t, errors.Is(err, ErrVersionNotCreated), "expected version not created, got %v", err)
+}
+
+func TestReleasePlan_Lines(t *testing.T) {
+	report := Report{Issues: []IssueResult{
+		{Key: "JR-1", Status: IssueStatusAlreadyLinked, Transition: TransitionStatusDone, Comment: CommentStatusDone},
+		{Key: "JR-2", Status: IssueStatusAlreadyLinked, Transition: TransitionStatusSkipped, Comment: CommentStatusAlreadyDone},
+		{Key: "JR-3", Status: IssueStatusAlreadyLinked, Transition: TransitionStatusUnavailable},
+	}}
+
+	tests := []struct {
+		name string
+		plan ReleasePlan
+		want []string
+	}{
+		{
+			name: "should list release, transitions and comments",
+			plan: ReleasePlan{Version: "v2.1.0", Report: report},
+			want: []string{
+				"release-version v2.1.0",
+				"transition JR-1",
+				"transition-skipped JR-2",
+				"transition-unavailable JR-3",
+				"comment JR-1",
+				"already-commented JR-2",
+			},
+		},
+		{
+			name: "should keep released version",
+			plan: ReleasePlan{Version: "v2.1.0", AlreadyReleased: true},
+			want: []string{"already-released v2.1.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.plan.Lines())
+		})
+	}
+}
+
+func TestMergePlan_Lines(t *testing.T) {
+	report := Report{Version: "v2.1.1"}
+	report.add("JR-1", IssueStatusLinked, nil)
+	report.add("JR-2", IssueStatusLinked, nil)
+
+	assert.Equal(t, []string{"move JR-1", "move JR-2", "delete-version v2.1.0"}, MergePlan{Source: "v2.1.0", Report: report}.Lines())
+	assert.Equal(t, []string{"rename-version v2.1.0 v2.1.1"}, RenamePlan{Version: "v2.1.0", NewName: "v2.1.1"}.Lines())
+}
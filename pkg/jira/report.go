@@ -0,0 +1,73 @@
+package jira
+
+// IssueStatus is result of processing single task
+type IssueStatus string
+
+const (
+	// IssueStatusLinked means task was linked to version
+	IssueStatusLinked IssueStatus = "linked"
+	// IssueStatusAlreadyLinked means task had the version before, so it was skipped
+	IssueStatusAlreadyLinked IssueStatus = "already linked"
+	// IssueStatusRemoved means version was removed from task in sync mode
+	IssueStatusRemoved IssueStatus = "removed"
+	// IssueStatusFailed means task couldn't be updated
+	IssueStatusFailed IssueStatus = "failed"
+)
+
+// IssueResult keeps result of processing single task
+type IssueResult struct {
+	Key    string
+	Status IssueStatus
+	Err    error
+	// Transition is result of moving task through workflow, empty if transition wasn't configured
+	Transition    TransitionStatus
+	TransitionErr error
+	// Comment is result of commenting task with release information, empty if comments weren't enabled
+	Comment    CommentStatus
+	CommentErr error
+	// Labels is result of editing task labels, empty if no label edits were configured
+	Labels    LabelStatus
+	LabelsErr error
+}
+
+// Report summarizes processing all tasks
+type Report struct {
+	Version string
+	Issues  []IssueResult
+	// Retries are statistics of retried HTTP requests
+	Retries RetryStats
+}
+
+// Status is result of one of the steps applied to a task: IssueStatus, TransitionStatus, CommentStatus or LabelStatus
+type Status interface {
+	matches(issue IssueResult) bool
+}
+
+func (s IssueStatus) matches(issue IssueResult) bool      { return issue.Status == s }
+func (s TransitionStatus) matches(issue IssueResult) bool { return issue.Transition == s }
+func (s CommentStatus) matches(issue IssueResult) bool    { return issue.Comment == s }
+func (s LabelStatus) matches(issue IssueResult) bool      { return issue.Labels == s }
+
+// Keys returns keys of tasks with given status of the step the status belongs to
+func (r Report) Keys(status Status) []string {
+	var keys []string
+	for _, issue := range r.Issues {
+		if status.matches(issue) {
+			keys = append(keys, issue.Key)
+		}
+	}
+	return keys
+}
+
+// HasFailures checks if any task couldn't be updated
+func (r Report) HasFailures() bool {
+	return len(r.Keys(IssueStatusFailed)) > 0
+}
+
+func (r *Report) add(key string, status IssueStatus, err error) {
+	r.Issues = append(r.Issues, IssueResult{
+		Key:    key,
+		Status: status,
+		Err:    err,
+	})
+}
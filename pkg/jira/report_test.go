@@ -0,0 +1,29 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport_Keys(t *testing.T) {
+	report := Report{Issues: []IssueResult{
+		{Key: "JR-1", Status: IssueStatusLinked, Transition: TransitionStatusDone, Comment: CommentStatusFailed},
+		{Key: "JR-2", Status: IssueStatusAlreadyLinked, Transition: TransitionStatusSkipped, Labels: LabelStatusFailed},
+		{Key: "JR-3", Status: IssueStatusFailed},
+	}}
+
+	tests := []struct {
+		status Status
+		want   []string
+	}{
+		{status: IssueStatusFailed, want: []string{"JR-3"}},
+		{status: TransitionStatusDone, want: []string{"JR-1"}},
+		{status: TransitionStatusFailed, want: nil},
+		{status: CommentStatusFailed, want: []string{"JR-1"}},
+		{status: LabelStatusFailed, want: []string{"JR-2"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, report.Keys(tt.status), "%T %s", tt.status, tt.status)
+	}
+}
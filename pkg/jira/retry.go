@@ -1,24 +1,158 @@
 package jira
 
 import (
+	"bytes"
 	"context"
 	"io/ioutil"
+	"math"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
-// retryPolicy implements CheckRetry interface to log more information about request fails
+const (
+	// DefaultRetryMaxWait limits single wait between retries when no other limit is configured
+	DefaultRetryMaxWait = 30 * time.Second
+	// retryMinWait is first wait of exponential backoff
+	retryMinWait = 1 * time.Second
+)
+
+// RetryStats keeps number of retried requests and total time spent waiting before retries
+type RetryStats struct {
+	Attempts  int
+	TotalWait time.Duration
+}
+
+// retryStats is shared between copies of Jira, so it's safe to use from http client
+type retryStats struct {
+	mu    sync.Mutex
+	stats RetryStats
+}
+
+func (s *retryStats) record(wait time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Attempts++
+	s.stats.TotalWait += wait
+}
+
+func (s *retryStats) get() RetryStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// RetryStats returns retry statistics of all requests made so far
+func (j Jira) RetryStats() RetryStats {
+	if j.retries == nil {
+		return RetryStats{}
+	}
+	return j.retries.get()
+}
+
+// retryPolicy implements CheckRetry interface to log more information about request fails,
+// response is nil when request failed at transport level
 func (j *Jira) retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
-	shouldRetry, err := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	shouldRetry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	if shouldRetry && resp == nil {
+		j.log.Warnf("HTTP request failed without response (%s), retrying ...", err)
+		return shouldRetry, checkErr
+	}
 	if shouldRetry {
 		j.log.Warnf("HTTP request failed with code %d, retrying ...", resp.StatusCode)
 		body, bodyErr := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
 		if bodyErr != nil {
 			return true, bodyErr
 		}
 		j.log.Debugf("HTTP request response body: %s", body)
+		// last response is passed to go-jira when retries are exhausted, so body has to be readable again
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		// error would make http.Client drop the response, status is checked by go-jira anyway
+		return true, nil
 	}
 
-	return shouldRetry, err
+	return shouldRetry, checkErr
+}
+
+// backoff implements Backoff interface, it waits as long as Jira asks in Retry-After or X-RateLimit-Reset headers,
+// otherwise it uses exponential backoff with jitter, wait never exceeds max
+func (j *Jira) backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	wait, ok := rateLimitWait(resp, time.Now())
+	if ok {
+		j.log.Infof("[JIRA] rate limited, waiting %s as requested by Jira", wait)
+	} else {
+		wait = exponentialWait(min, max, attemptNum)
+	}
+	wait += jitter(wait)
+
+	if wait > max {
+		wait = max
+	}
+	if j.retries != nil {
+		j.retries.record(wait)
+	}
+
+	return wait
+}
+
+// rateLimitWait reads wait time from Retry-After (seconds or HTTP date) or X-RateLimit-Reset (timestamp) headers
+func rateLimitWait(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return positive(date.Sub(now)), true
+		}
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests && resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	if value := resp.Header.Get("X-RateLimit-Reset"); value != "" {
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00"} {
+			if reset, err := time.Parse(layout, value); err == nil {
+				return positive(reset.Sub(now)), true
+			}
+		}
+		if epoch, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return positive(time.Unix(epoch, 0).Sub(now)), true
+		}
+	}
+
+	return 0, false
+}
+
+// exponentialWait doubles wait with each attempt starting from min, up to max
+func exponentialWait(min, max time.Duration, attemptNum int) time.Duration {
+	wait := float64(min) * math.Pow(2, float64(attemptNum))
+	if wait > float64(max) {
+		return max
+	}
+	return time.Duration(wait)
+}
+
+// jitter returns random duration up to 10% of wait, so parallel pipelines don't retry at the same moment
+func jitter(wait time.Duration) time.Duration {
+	maxJitter := int64(wait / 10)
+	if maxJitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(maxJitter)) //nolint:gosec // jitter doesn't need crypto random
+}
+
+func positive(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
 }
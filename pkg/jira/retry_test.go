@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    int
+		headers   map[string]string
+		want      time.Duration
+		wantFound bool
+	}{
+		{
+			name:      "should read Retry-After seconds",
+			status:    http.StatusTooManyRequests,
+			headers:   map[string]string{"Retry-After": "7"},
+			want:      7 * time.Second,
+			wantFound: true,
+		},
+		{
+			name:      "should read Retry-After HTTP date",
+			status:    http.StatusServiceUnavailable,
+			headers:   map[string]string{"Retry-After": "Thu, 01 Apr 2021 12:00:30 GMT"},
+			want:      30 * time.Second,
+			wantFound: true,
+		},
+		{
+			name:      "should read X-RateLimit-Reset timestamp",
+			status:    http.StatusTooManyRequests,
+			headers:   map[string]string{"X-RateLimit-Reset": "2021-04-01T12:01Z"},
+			want:      time.Minute,
+			wantFound: true,
+		},
+		{
+			name:      "should ignore X-RateLimit-Reset when limit is not exhausted",
+			status:    http.StatusBadGateway,
+			headers:   map[string]string{"X-RateLimit-Reset": "2021-04-01T12:01Z", "X-RateLimit-Remaining": "10"},
+			wantFound: false,
+		},
+		{
+			name:      "should not wait for reset in the past",
+			status:    http.StatusTooManyRequests,
+			headers:   map[string]string{"X-RateLimit-Reset": "2021-04-01T11:00:00Z"},
+			want:      0,
+			wantFound: true,
+		},
+		{
+			name:      "should fall back without headers",
+			status:    http.StatusInternalServerError,
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			for k, v := range tt.headers {
+				resp.Header.Set(k, v)
+			}
+
+			got, found := rateLimitWait(resp, now)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJira_backoff(t *testing.T) {
+	j := &Jira{log: zap.NewExample().Sugar(), retries: &retryStats{}}
+
+	rateLimited := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	rateLimited.Header.Set("Retry-After", "120")
+
+	wait := j.backoff(time.Second, 10*time.Second, 0, rateLimited)
+	assert.Equal(t, 10*time.Second, wait, "wait should be limited by max")
+
+	wait = j.backoff(time.Second, 10*time.Second, 2, nil)
+	assert.GreaterOrEqual(t, int64(wait), int64(4*time.Second))
+	assert.Less(t, int64(wait), int64(5*time.Second))
+
+	stats := j.RetryStats()
+	assert.Equal(t, 2, stats.Attempts)
+	assert.Equal(t, 10*time.Second+wait, stats.TotalWait)
+}
@@ -0,0 +1,136 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pkg/errors"
+)
+
+// issuePageSize is number of issues fetched in one search request
+const issuePageSize = 50
+
+// SyncPlan lists changes needed to make version contain exactly given tasks
+type SyncPlan struct {
+	Add    []string
+	Remove []string
+	// Linked are all issues having the version before sync
+	Linked []string
+}
+
+// GetVersionIssues finds keys of all issues which currently have the version in configured field
+func (j Jira) GetVersionIssues(ctx context.Context) ([]string, error) {
+	var keys []string
+	if j.Version == nil || j.Version.ID == "" {
+		// version doesn't exist yet, so there are no issues linked to it
+		return keys, nil
+	}
+
+	// issues are paged manually, go-jira uses context only for the first page
+	jql := fmt.Sprintf("%s = %s", j.jqlField(), j.Version.ID)
+	options := &jira.SearchOptions{Fields: []string{"key"}, MaxResults: issuePageSize}
+	for {
+		issues, res, err := j.Client.Issue.SearchWithContext(ctx, jql, options)
+		if err != nil {
+			return keys, errors.Wrapf(responseError(res, err), "can't search issues with version %s", j.Version.Name)
+		}
+		for _, issue := range issues {
+			keys = append(keys, issue.Key)
+		}
+		if len(issues) == 0 || res.StartAt+len(issues) >= res.Total {
+			break
+		}
+		options.StartAt += len(issues)
+	}
+	j.log.Debugf("[JIRA] found %d issues with version %s", len(keys), j.Version.Name)
+
+	return keys, nil
+}
+
+// PlanSync compares tasks found in git with issues already having the version
+func (j Jira) PlanSync(ctx context.Context, taskIds []string) (SyncPlan, error) {
+	var plan SyncPlan
+
+	linked, err := j.GetVersionIssues(ctx)
+	if err != nil {
+		return plan, err
+	}
+
+	plan.Add = difference(taskIds, linked)
+	plan.Remove = difference(linked, taskIds)
+	plan.Linked = linked
+
+	return plan, nil
+}
+
+// LinkedTasksReport reports which of given tasks are already linked to version, other tasks are left out,
+// so following steps like transitions and comments apply only to linked tasks
+func (j Jira) LinkedTasksReport(ctx context.Context, taskIds []string) (Report, error) {
+	report := Report{Version: j.Version.Name}
+
+	linked, err := j.GetVersionIssues(ctx)
+	if err != nil {
+		return report, err
+	}
+	isLinked := make(map[string]struct{}, len(linked))
+	for _, taskID := range linked {
+		isLinked[taskID] = struct{}{}
+	}
+
+	for _, taskID := range taskIds {
+		if _, ok := isLinked[taskID]; !ok {
+			j.log.Infof("[JIRA] task %s not linked to %s, skipping", taskID, j.Version.Name)
+			continue
+		}
+		report.add(taskID, IssueStatusAlreadyLinked, nil)
+	}
+
+	return report, nil
+}
+
+// SyncTasksWithVersion links tasks to version and removes version from issues no longer in given tasks
+func (j Jira) SyncTasksWithVersion(ctx context.Context, taskIds []string) (Report, error) {
+	plan, err := j.PlanSync(ctx, taskIds)
+	if err != nil {
+		return Report{Version: j.Version.Name}, err
+	}
+
+	for _, taskID := range plan.Add {
+		j.log.Infof("[JIRA] sync plan: + %s", taskID)
+	}
+	for _, taskID := range plan.Remove {
+		j.log.Infof("[JIRA] sync plan: - %s", taskID)
+	}
+
+	report := j.linkTasks(ctx, taskIds, plan.Linked)
+
+	for _, taskID := range plan.Remove {
+		removeErr := j.RemoveIssueVersion(ctx, taskID)
+		if removeErr != nil {
+			j.log.Warnf("[JIRA] can't remove version %s (%s) from task %s", j.Version.Name, j.Version.ID, taskID)
+			report.add(taskID, IssueStatusFailed, removeErr)
+			continue
+		}
+		report.add(taskID, IssueStatusRemoved, nil)
+	}
+
+	return report, nil
+}
+
+// difference returns items from a which are not in b, order of a is kept
+func difference(a, b []string) []string {
+	exclude := make(map[string]struct{}, len(b))
+	for _, item := range b {
+		exclude[item] = struct{}{}
+	}
+
+	var result []string
+	for _, item := range a {
+		if _, ok := exclude[item]; !ok {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,129 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJira_GetVersionIssues_Paginated(t *testing.T) {
+	var startAts []string
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/search": func(w http.ResponseWriter, r *http.Request) {
+			startAt := r.URL.Query().Get("startAt")
+			startAts = append(startAts, startAt)
+			w.Header().Set("Content-Type", "application/json")
+			// server returns fewer issues than asked for, like Jira Cloud limiting maxResults
+			switch startAt {
+			case "":
+				_, _ = fmt.Fprint(w, `{"startAt": 0, "maxResults": 2, "total": 3, "issues": [{"key": "JR-1"}, {"key": "JR-2"}]}`)
+			case "2":
+				_, _ = fmt.Fprint(w, `{"startAt": 2, "maxResults": 2, "total": 3, "issues": [{"key": "JR-3"}]}`)
+			default:
+				t.Errorf("unexpected startAt %s", startAt)
+			}
+		},
+	})
+	j := newTestJira(t, server)
+
+	keys, err := j.GetVersionIssues(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JR-1", "JR-2", "JR-3"}, keys)
+	assert.Equal(t, []string{"", "2"}, startAts)
+}
+
+func TestJira_GetVersionIssues_CanceledBetweenPages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	requests := 0
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/search": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			cancel()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"startAt": 0, "maxResults": 1, "total": 2, "issues": [{"key": "JR-1"}]}`)
+		},
+	})
+	j := newTestJira(t, server)
+
+	_, err := j.GetVersionIssues(ctx)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+// linkedIssues serves issue search returning given issues as linked to version
+func linkedIssues(keys ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		issues := make([]string, 0, len(keys))
+		for _, key := range keys {
+			issues = append(issues, fmt.Sprintf(`{"key": %q}`, key))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"startAt": 0, "maxResults": 50, "total": %d, "issues": [%s]}`, len(keys), strings.Join(issues, ","))
+	}
+}
+
+func TestJira_PlanSync(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/search": linkedIssues("JR-1", "JR-3", "JR-4"),
+	})
+	j := newTestJira(t, server)
+
+	plan, err := j.PlanSync(context.Background(), []string{"JR-1", "JR-2", "JR-4", "JR-5"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JR-2", "JR-5"}, plan.Add)
+	assert.Equal(t, []string{"JR-3"}, plan.Remove)
+	assert.Equal(t, []string{"JR-1", "JR-3", "JR-4"}, plan.Linked)
+}
+
+func TestJira_SyncTasksWithVersion(t *testing.T) {
+	var updates []string
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/search": linkedIssues("JR-1", "JR-3"),
+		"/rest/api/2/issue/": func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			updates = append(updates, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	j := newTestJira(t, server)
+
+	report, err := j.SyncTasksWithVersion(context.Background(), []string{"JR-1", "JR-2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JR-2"}, report.Keys(IssueStatusLinked))
+	assert.Equal(t, []string{"JR-1"}, report.Keys(IssueStatusAlreadyLinked))
+	assert.Equal(t, []string{"JR-3"}, report.Keys(IssueStatusRemoved))
+	assert.Equal(t, []string{
+		`PUT /rest/api/2/issue/JR-2 {"update":{"fixVersions":[{"add":{"id":"10100"}}]}}`,
+		`PUT /rest/api/2/issue/JR-3 {"update":{"fixVersions":[{"remove":{"id":"10100"}}]}}`,
+	}, updates)
+}
+
+func TestJira_SyncTasksWithVersion_DryRun(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/search": linkedIssues("JR-1", "JR-3"),
+		"/rest/api/2/issue/": func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request %s %s in dry run", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	j := newTestJira(t, server)
+	j.dryRun = true
+
+	report, err := j.SyncTasksWithVersion(context.Background(), []string{"JR-1", "JR-2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JR-2"}, report.Keys(IssueStatusLinked))
+	assert.Equal(t, []string{"JR-3"}, report.Keys(IssueStatusRemoved))
+}
@@ -0,0 +1,95 @@
+package jira
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pkg/errors"
+)
+
+// TransitionStatus is result of moving single task through workflow
+type TransitionStatus string
+
+const (
+	// TransitionStatusDone means transition was applied
+	TransitionStatusDone TransitionStatus = "transitioned"
+	// TransitionStatusSkipped means task is not in any of configured source statuses
+	TransitionStatusSkipped TransitionStatus = "skipped"
+	// TransitionStatusUnavailable means transition can't be applied from task current status
+	TransitionStatusUnavailable TransitionStatus = "unavailable"
+	// TransitionStatusFailed means transition couldn't be checked or applied
+	TransitionStatusFailed TransitionStatus = "failed"
+)
+
+// TransitionLinkedTasks moves tasks linked to version through workflow using configured transition,
+// only tasks in configured source statuses are moved, results are put into the report
+func (j Jira) TransitionLinkedTasks(ctx context.Context, report Report) Report {
+	for i := range report.Issues {
+		issue := &report.Issues[i]
+		if issue.Status != IssueStatusLinked && issue.Status != IssueStatusAlreadyLinked {
+			continue
+		}
+
+		issue.Transition, issue.TransitionErr = j.transitionTask(ctx, issue.Key)
+		if issue.TransitionErr != nil {
+			j.log.Warnf("[JIRA] can't transition task %s with %s (%s)", issue.Key, j.transition, issue.TransitionErr)
+		}
+	}
+
+	return report
+}
+
+// transitionTask applies configured transition to single task
+func (j Jira) transitionTask(ctx context.Context, taskID string) (TransitionStatus, error) {
+	issue, res, err := j.Client.Issue.GetWithContext(ctx, taskID, &jira.GetQueryOptions{Fields: "status"})
+	if err != nil {
+		return TransitionStatusFailed, errors.Wrapf(responseError(res, err), "can't get status of task %s", taskID)
+	}
+
+	status := ""
+	if issue.Fields != nil && issue.Fields.Status != nil {
+		status = issue.Fields.Status.Name
+	}
+	if !j.isTransitionSource(status) {
+		j.log.Debugf("[JIRA] task %s is in status %s, skipping transition", taskID, status)
+		return TransitionStatusSkipped, nil
+	}
+
+	transitions, res, err := j.Client.Issue.GetTransitionsWithContext(ctx, taskID)
+	if err != nil {
+		return TransitionStatusFailed, errors.Wrapf(responseError(res, err), "can't get transitions of task %s", taskID)
+	}
+
+	for _, transition := range transitions {
+		if !strings.EqualFold(transition.Name, j.transition) {
+			continue
+		}
+
+		if !j.dryRun {
+			res, err = j.Client.Issue.DoTransitionWithContext(ctx, taskID, transition.ID)
+			if err != nil {
+				return TransitionStatusFailed, errors.Wrapf(responseError(res, err), "can't apply transition %s to task %s", transition.Name, taskID)
+			}
+		}
+		j.log.Infof("[JIRA] task %s transitioned from %s to %s", taskID, status, transition.To.Name)
+
+		return TransitionStatusDone, nil
+	}
+
+	j.log.Warnf("[JIRA] transition %s is not available for task %s in status %s", j.transition, taskID, status)
+	return TransitionStatusUnavailable, nil
+}
+
+// isTransitionSource checks if tasks in given status should be transitioned, empty sources allow all statuses
+func (j Jira) isTransitionSource(status string) bool {
+	if len(j.transitionFrom) == 0 {
+		return true
+	}
+	for _, from := range j.transitionFrom {
+		if strings.EqualFold(from, status) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJira_TransitionLinkedTasks(t *testing.T) {
+	tests := []struct {
+		name       string
+		transition string
+		from       []string
+		want       TransitionStatus
+		applied    string
+	}{
+		{
+			name:       "should resolve transition by name",
+			transition: "released",
+			want:       TransitionStatusDone,
+			applied:    `{"transition": {"id": "31"}, "fields": {}}`,
+		},
+		{
+			name:       "should transition task in source status",
+			transition: "Released",
+			from:       []string{"To Do", "In Progress"},
+			want:       TransitionStatusDone,
+			applied:    `{"transition": {"id": "31"}, "fields": {}}`,
+		},
+		{
+			name:       "should skip task in other status",
+			transition: "Released",
+			from:       []string{"In Review"},
+			want:       TransitionStatusSkipped,
+		},
+		{
+			name:       "should report transition missing in task workflow",
+			transition: "Archive",
+			want:       TransitionStatusUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var applied string
+			server := newTestServer(t, map[string]http.HandlerFunc{
+				"/rest/api/2/issue/JR-1": func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "status", r.URL.Query().Get("fields"))
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = fmt.Fprint(w, `{"key": "JR-1", "fields": {"status": {"name": "In Progress"}}}`)
+				},
+				"/rest/api/2/issue/JR-1/transitions": func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == http.MethodPost {
+						body, err := ioutil.ReadAll(r.Body)
+						require.NoError(t, err)
+						applied = string(body)
+						w.WriteHeader(http.StatusNoContent)
+						return
+					}
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = fmt.Fprint(w, `{"transitions": [
+						{"id": "21", "name": "Review", "to": {"name": "In Review"}},
+						{"id": "31", "name": "Released", "to": {"name": "Done"}}
+					]}`)
+				},
+			})
+			j := newTestJira(t, server)
+			j.transition = tt.transition
+			j.transitionFrom = tt.from
+			report := Report{Version: "v1.0.0"}
+			report.add("JR-1", IssueStatusLinked, nil)
+
+			report = j.TransitionLinkedTasks(context.Background(), report)
+
+			require.NoError(t, report.Issues[0].TransitionErr)
+			assert.Equal(t, tt.want, report.Issues[0].Transition)
+			if tt.applied == "" {
+				assert.Empty(t, applied)
+				return
+			}
+			assert.JSONEq(t, tt.applied, applied)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package jira
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pkg/errors"
+)
+
+// statusCategoryDone is key of status category of closed issues
+const statusCategoryDone = "done"
+
+// VerifyStatus is result of verifying single task
+type VerifyStatus string
+
+const (
+	// VerifyStatusOK means task exists in allowed project and status
+	VerifyStatusOK VerifyStatus = "ok"
+	// VerifyStatusMissing means task doesn't exist or account can't see it
+	VerifyStatusMissing VerifyStatus = "missing"
+	// VerifyStatusClosed means task is in disallowed status
+	VerifyStatusClosed VerifyStatus = "closed"
+	// VerifyStatusWrongProject means task belongs to project which is not allowed
+	VerifyStatusWrongProject VerifyStatus = "wrong project"
+	// VerifyStatusFailed means task couldn't be checked
+	VerifyStatusFailed VerifyStatus = "failed"
+)
+
+// VerifyRules decide which tasks pass verification
+type VerifyRules struct {
+	// Projects are allowed project keys, project of Jira instance if empty
+	Projects []string
+	// DisallowedStatuses are names of statuses tasks can't be in,
+	// if empty tasks in statuses of done category are disallowed
+	DisallowedStatuses []string
+}
+
+// VerifyResult is result of verifying single task
+type VerifyResult struct {
+	Key     string
+	Status  VerifyStatus
+	Project string
+	// IssueStatus is name of Jira status the task is in
+	IssueStatus string
+	Err         error
+}
+
+// VerifyReport keeps results of verifying all tasks
+type VerifyReport struct {
+	Issues []VerifyResult
+}
+
+// Keys returns keys of tasks with given status in the order they were verified
+func (r VerifyReport) Keys(status VerifyStatus) []string {
+	var keys []string
+	for _, issue := range r.Issues {
+		if issue.Status == status {
+			keys = append(keys, issue.Key)
+		}
+	}
+	return keys
+}
+
+// HasProblems checks if any task didn't pass verification
+func (r VerifyReport) HasProblems() bool {
+	for _, issue := range r.Issues {
+		if issue.Status != VerifyStatusOK {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyTasks checks if tasks exist, belong to allowed projects and aren't in disallowed statuses
+func (j Jira) VerifyTasks(ctx context.Context, taskIds []string, rules VerifyRules) VerifyReport {
+	var report VerifyReport
+	projects := rules.Projects
+	if len(projects) == 0 {
+		projects = []string{j.Project.Key}
+	}
+
+	for _, taskID := range taskIds {
+		result := j.verifyTask(ctx, taskID, projects, rules.DisallowedStatuses)
+		if result.Status != VerifyStatusOK {
+			j.log.Warnf("[JIRA] task %s didn't pass verification: %s", taskID, result.Status)
+		}
+		report.Issues = append(report.Issues, result)
+	}
+
+	return report
+}
+
+// verifyTask checks single task
+func (j Jira) verifyTask(ctx context.Context, taskID string, projects, disallowedStatuses []string) VerifyResult {
+	result := VerifyResult{Key: taskID}
+
+	issue, res, err := j.Client.Issue.GetWithContext(ctx, taskID, &jira.GetQueryOptions{Fields: "project,status"})
+	if err != nil {
+		err = responseError(res, err)
+		if errors.Is(err, ErrNotFound) {
+			result.Status = VerifyStatusMissing
+			return result
+		}
+		result.Status = VerifyStatusFailed
+		result.Err = errors.Wrapf(err, "can't get task %s", taskID)
+		return result
+	}
+
+	if issue.Fields != nil {
+		result.Project = issue.Fields.Project.Key
+		if issue.Fields.Status != nil {
+			result.IssueStatus = issue.Fields.Status.Name
+		}
+	}
+	j.log.Debugf("[JIRA] task %s is in project %s with status %s", taskID, result.Project, result.IssueStatus)
+
+	switch {
+	case !containsFold(projects, result.Project):
+		result.Status = VerifyStatusWrongProject
+	case isDisallowedStatus(issue, disallowedStatuses):
+		result.Status = VerifyStatusClosed
+	default:
+		result.Status = VerifyStatusOK
+	}
+
+	return result
+}
+
+// isDisallowedStatus checks if issue is in one of given statuses, or in done category when none is given
+func isDisallowedStatus(issue *jira.Issue, disallowedStatuses []string) bool {
+	if issue.Fields == nil || issue.Fields.Status == nil {
+		return false
+	}
+	if len(disallowedStatuses) == 0 {
+		return issue.Fields.Status.StatusCategory.Key == statusCategoryDone
+	}
+
+	return containsFold(disallowedStatuses, issue.Fields.Status.Name)
+}
+
+// containsFold checks if list contains value ignoring case
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
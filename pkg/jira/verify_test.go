@@ -0,0 +1,65 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// issueWithStatus serves issue in given project and status
+func issueWithStatus(key, project, status, category string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id": "1", "key": "%s", "fields": {"project": {"key": "%s"},
+			"status": {"name": "%s", "statusCategory": {"key": "%s"}}}}`, key, project, status, category)
+	}
+}
+
+func TestJira_VerifyTasks(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/issue/JR-1":  issueWithStatus("JR-1", "JR", "In Progress", "indeterminate"),
+		"/rest/api/2/issue/JR-2":  issueWithStatus("JR-2", "JR", "Done", "done"),
+		"/rest/api/2/issue/OPS-3": issueWithStatus("OPS-3", "OPS", "To Do", "new"),
+		"/rest/api/2/issue/JR-4":  issueWithStatus("JR-4", "JR", "Won't Do", "done"),
+		"/rest/api/2/issue/JR-9": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"errorMessages": ["Issue does not exist or you do not have permission to see it."], "errors": {}}`)
+		},
+		"/rest/api/2/issue/JR-5": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		},
+	})
+	j := newTestJira(t, server)
+	keys := []string{"JR-1", "JR-2", "OPS-3", "JR-4", "JR-9", "JR-5"}
+
+	t.Run("should reject done tasks by default", func(t *testing.T) {
+		report := j.VerifyTasks(context.Background(), keys, VerifyRules{})
+
+		assert.True(t, report.HasProblems())
+		assert.Equal(t, []string{"JR-1"}, report.Keys(VerifyStatusOK))
+		assert.Equal(t, []string{"JR-2", "JR-4"}, report.Keys(VerifyStatusClosed))
+		assert.Equal(t, []string{"OPS-3"}, report.Keys(VerifyStatusWrongProject))
+		assert.Equal(t, []string{"JR-9"}, report.Keys(VerifyStatusMissing))
+		assert.Equal(t, []string{"JR-5"}, report.Keys(VerifyStatusFailed))
+	})
+
+	t.Run("should use given projects and statuses", func(t *testing.T) {
+		report := j.VerifyTasks(context.Background(), []string{"JR-1", "JR-2", "OPS-3", "JR-4"}, VerifyRules{
+			Projects:           []string{"jr", "OPS"},
+			DisallowedStatuses: []string{"won't do"},
+		})
+
+		assert.Equal(t, []string{"JR-1", "JR-2", "OPS-3"}, report.Keys(VerifyStatusOK))
+		assert.Equal(t, []string{"JR-4"}, report.Keys(VerifyStatusClosed))
+	})
+
+	t.Run("should pass when all tasks are fine", func(t *testing.T) {
+		report := j.VerifyTasks(context.Background(), []string{"JR-1"}, VerifyRules{})
+
+		assert.False(t, report.HasProblems())
+	})
+}
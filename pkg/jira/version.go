@@ -0,0 +1,304 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pkg/errors"
+)
+
+// versionPageSize is number of versions fetched in single page of version search
+const versionPageSize = 50
+
+// versionPage is single page of paginated project versions response
+type versionPage struct {
+	StartAt    int            `json:"startAt"`
+	MaxResults int            `json:"maxResults"`
+	Total      int            `json:"total"`
+	IsLast     bool           `json:"isLast"`
+	Values     []jira.Version `json:"values"`
+}
+
+// searchVersion looks for version with exactly given name using paginated project versions endpoint,
+// returns ErrNotFound if the endpoint is not available
+func (j Jira) searchVersion(ctx context.Context, name string) (*jira.Version, bool, error) {
+	for startAt := 0; ; {
+		page, err := j.getVersionPage(ctx, name, startAt)
+		if err != nil {
+			return &jira.Version{}, false, err
+		}
+		for i := range page.Values {
+			if page.Values[i].Name == name {
+				return &page.Values[i], true, nil
+			}
+		}
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 || (page.Total > 0 && startAt >= page.Total) {
+			return &jira.Version{}, false, nil
+		}
+	}
+}
+
+// getVersionPage fetches single page of project versions matching query
+func (j Jira) getVersionPage(ctx context.Context, query string, startAt int) (versionPage, error) {
+	var page versionPage
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("startAt", fmt.Sprint(startAt))
+	params.Set("maxResults", fmt.Sprint(versionPageSize))
+	u := fmt.Sprintf("/rest/api/2/project/%s/version?%s", j.ProjectID, params.Encode())
+	req, err := j.Client.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return page, errors.Wrapf(err, "can't create Jira request to %s", u)
+	}
+	res, err := j.Client.Do(req, &page)
+	if err != nil {
+		return page, errors.Wrap(responseError(res, err), "can't search project versions")
+	}
+
+	return page, nil
+}
+
+// getProjectVersions fetches current list of all project versions
+func (j Jira) getProjectVersions(ctx context.Context) ([]jira.Version, error) {
+	var versions []jira.Version
+
+	u := fmt.Sprintf("/rest/api/2/project/%s/versions", j.ProjectID)
+	req, err := j.Client.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't create Jira request to %s", u)
+	}
+	res, err := j.Client.Do(req, &versions)
+	if err != nil {
+		return nil, errors.Wrap(responseError(res, err), "can't get project versions")
+	}
+
+	return versions, nil
+}
+
+// adoptExistingVersion reloads project versions after create conflict, so version created in the meantime
+// by another run is used instead of failing
+func (j *Jira) adoptExistingVersion(ctx context.Context, name string, createErr error) (*jira.Version, error) {
+	j.log.Infof("[JIRA] version %s was created in the meantime, reloading project versions", name)
+
+	version, isFound, err := j.searchVersion(ctx, name)
+	if errors.Is(err, ErrNotFound) {
+		version, isFound, err = j.reloadVersion(ctx, name)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, createErr.Error())
+	}
+	if !isFound {
+		return nil, createErr
+	}
+
+	j.Version = version
+	j.log.Infof("[JIRA] using existing version %s (%s)", j.Version.Name, j.Version.ID)
+	return j.Version, nil
+}
+
+// reloadVersion refreshes embedded project versions and looks for given version name in them
+func (j *Jira) reloadVersion(ctx context.Context, name string) (*jira.Version, bool, error) {
+	versions, err := j.getProjectVersions(ctx)
+	if err != nil {
+		return &jira.Version{}, false, err
+	}
+	j.Project.Versions = versions
+
+	return findVersion(versions, name)
+}
+
+// findVersion looks for version with given name in the list
+func findVersion(versions []jira.Version, name string) (*jira.Version, bool, error) {
+	for i := range versions {
+		if versions[i].Name == name {
+			return &versions[i], true, nil
+		}
+	}
+
+	return &jira.Version{}, false, nil
+}
+
+// RenameVersion changes name of existing version
+func (j Jira) RenameVersion(ctx context.Context, name, newName string) (*jira.Version, error) {
+	err := ValidateVersionName(newName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := j.findExistingVersion(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	_, isFound, err := j.GetVersion(ctx, newName)
+	if err != nil {
+		return nil, err
+	}
+	if isFound {
+		return nil, errors.Wrapf(ErrVersionConflict, "can't rename version %s to %s", name, newName)
+	}
+
+	j.log.Debugf("[JIRA] renaming version %s (%s) to %s", version.Name, version.ID, newName)
+	if j.dryRun {
+		version.Name = newName
+		return version, nil
+	}
+
+	renamed, res, err := j.Client.Version.UpdateWithContext(ctx, &jira.Version{ID: version.ID, Name: newName})
+	if err != nil {
+		return nil, errors.Wrapf(responseError(res, err), "can't rename version %s", name)
+	}
+	j.log.Infof("[JIRA] version %s renamed to %s", name, newName)
+
+	return renamed, nil
+}
+
+// MergeVersion moves all issues from source version to target one and deletes source version,
+// source version is kept when any issue can't be moved
+func (j Jira) MergeVersion(ctx context.Context, source, target string) (Report, error) {
+	report := Report{Version: target}
+
+	sourceVersion, err := j.findExistingVersion(ctx, source)
+	if err != nil {
+		return report, err
+	}
+	targetVersion, err := j.findExistingVersion(ctx, target)
+	if err != nil {
+		return report, err
+	}
+	if sourceVersion.ID == targetVersion.ID {
+		return report, errors.Errorf("can't merge version %s into itself", source)
+	}
+
+	j.Version = sourceVersion
+	keys, err := j.GetVersionIssues(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	for _, taskID := range keys {
+		moveErr := j.moveIssueVersion(ctx, taskID, sourceVersion, targetVersion)
+		if moveErr != nil {
+			j.log.Warnf("[JIRA] can't move task %s from %s to %s (%s)", taskID, source, target, moveErr)
+			report.add(taskID, IssueStatusFailed, moveErr)
+			continue
+		}
+		report.add(taskID, IssueStatusLinked, nil)
+	}
+	if report.HasFailures() {
+		return report, errors.Errorf("version %s not deleted, some of its issues weren't moved to %s", source, target)
+	}
+
+	err = j.deleteVersion(ctx, sourceVersion, targetVersion)
+	if err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// findExistingVersion looks for version with given name and fails if it doesn't exist
+func (j Jira) findExistingVersion(ctx context.Context, name string) (*jira.Version, error) {
+	version, isFound, err := j.GetVersion(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !isFound {
+		return nil, errors.Wrapf(ErrNotFound, "version %s", name)
+	}
+
+	return version, nil
+}
+
+// moveIssueVersion replaces source version with target one in single task
+func (j Jira) moveIssueVersion(ctx context.Context, taskID string, source, target *jira.Version) error {
+	p := UpdatePayload{
+		Update: UpdateTypePayload{
+			Field: j.field,
+			Versions: []VersionOperation{
+				{Add: &VersionID{ID: target.ID}},
+				{Remove: &VersionID{ID: source.ID}},
+			},
+		},
+	}
+
+	j.log.Debugf("[JIRA] moving task %s from %s to %s in %s", taskID, source.Name, target.Name, j.field)
+	err := j.updateIssue(ctx, taskID, p)
+	if err != nil {
+		return err
+	}
+
+	if !j.dryRun {
+		j.log.Infof("[JIRA] task %s moved to %s", taskID, target.Name)
+	}
+	return nil
+}
+
+// deleteVersion deletes version, issues still having it in fix or affects versions are moved to replacement
+func (j Jira) deleteVersion(ctx context.Context, version, replacement *jira.Version) error {
+	params := url.Values{}
+	params.Set("moveFixIssuesTo", replacement.ID)
+	params.Set("moveAffectedIssuesTo", replacement.ID)
+	u := fmt.Sprintf("/rest/api/2/version/%s?%s", version.ID, params.Encode())
+	req, err := j.Client.NewRequestWithContext(ctx, "DELETE", u, nil)
+	if err != nil {
+		return errors.Wrapf(err, "can't create Jira request to %s", u)
+	}
+
+	j.log.Debugf("[JIRA] deleting version %s (%s)", version.Name, version.ID)
+	if j.dryRun {
+		return nil
+	}
+
+	res, err := j.Client.Do(req, nil)
+	if err != nil {
+		return errors.Wrapf(responseError(res, err), "can't delete version %s", version.Name)
+	}
+	_ = res.Body.Close()
+	j.log.Infof("[JIRA] version deleted %s", version.Name)
+
+	return nil
+}
+
+// UseVersion selects existing version, so tasks can be linked to it without creating it
+func (j *Jira) UseVersion(ctx context.Context, name string) (*jira.Version, error) {
+	version, err := j.findExistingVersion(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	j.Version = version
+	j.log.Infof("[JIRA] using version %s (%s)", j.Version.Name, j.Version.ID)
+
+	return version, nil
+}
+
+// ReleaseVersion marks selected version as released today, already released version is left untouched
+func (j *Jira) ReleaseVersion(ctx context.Context) error {
+	if j.Version.Released {
+		j.log.Infof("[JIRA] version %s already released, skip releasing", j.Version.Name)
+		return nil
+	}
+
+	j.log.Debugf("[JIRA] releasing version %s (%s)", j.Version.Name, j.Version.ID)
+	if j.dryRun {
+		return nil
+	}
+
+	released := &jira.Version{ID: j.Version.ID, Released: true, ReleaseDate: time.Now().Format(releaseDateLayout)}
+	_, res, err := j.Client.Version.UpdateWithContext(ctx, released)
+	if err != nil {
+		return errors.Wrapf(responseError(res, err), "can't release version %s", j.Version.Name)
+	}
+	// go-jira returns request payload instead of Jira response, so selected version is only marked as released
+	version := *j.Version
+	version.Released = released.Released
+	version.ReleaseDate = released.ReleaseDate
+	j.Version = &version
+	j.log.Infof("[JIRA] version released %s", j.Version.Name)
+
+	return nil
+}
@@ -0,0 +1,357 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionStore simulates Jira keeping project versions, creating version with existing name fails
+type versionStore struct {
+	mu       sync.Mutex
+	versions []jira.Version
+	creates  int
+}
+
+func (s *versionStore) create(w http.ResponseWriter, r *http.Request) {
+	var v jira.Version
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creates++
+	w.Header().Set("Content-Type", "application/json")
+	for _, existing := range s.versions {
+		if existing.Name == v.Name {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"errorMessages": [], "errors": {"name": "A version with this name already exists in this project."}}`)
+			return
+		}
+	}
+	v.ID = strconv.Itoa(10100 + len(s.versions))
+	s.versions = append(s.versions, v)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *versionStore) list(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.versions)
+}
+
+func TestJira_CreateVersion_ConcurrentRuns(t *testing.T) {
+	store := &versionStore{versions: []jira.Version{{ID: "10100", Name: "v1.0.0"}}}
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/version":                store.create,
+		"/rest/api/2/project/10000/versions": store.list,
+	})
+
+	// both runs start with project snapshot without the new version
+	runs := []Jira{newTestJira(t, server), newTestJira(t, server)}
+	versions := make([]*jira.Version, len(runs))
+	errs := make([]error, len(runs))
+
+	var wg sync.WaitGroup
+	for i := range runs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			versions[i], errs[i] = runs[i].CreateVersion(context.Background(), "v1.1.0", "")
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range runs {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "10101", versions[i].ID)
+		assert.Equal(t, "10101", runs[i].Version.ID)
+	}
+	assert.Equal(t, 2, store.creates)
+	assert.Len(t, store.versions, 2)
+}
+
+func TestJira_CreateVersion_ConflictWithoutVersion(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/version": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"errorMessages": [], "errors": {"name": "A version with this name already exists in this project."}}`)
+		},
+		"/rest/api/2/project/10000/versions": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `[{"id": "10100", "name": "v1.0.0"}]`)
+		},
+	})
+	j := newTestJira(t, server)
+
+	_, err := j.CreateVersion(context.Background(), "v1.1.0", "")
+
+	assert.True(t, errors.Is(err, ErrVersionConflict), "expected version conflict, got %v", err)
+}
+
+func TestJira_GetVersion_Paginated(t *testing.T) {
+	var queries []string
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/project/10000/version": func(w http.ResponseWriter, r *http.Request) {
+			queries = append(queries, r.URL.RawQuery)
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("startAt") == "0" {
+				_, _ = fmt.Fprint(w, `{"startAt": 0, "maxResults": 2, "total": 3, "isLast": false, "values": [
+					{"id": "10200", "name": "v2.1.0-rc1"}, {"id": "10201", "name": "web-v2.1.0"}]}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"startAt": 2, "maxResults": 2, "total": 3, "isLast": true, "values": [
+				{"id": "10202", "name": "v2.1.0"}]}`)
+		},
+	})
+	j := newTestJira(t, server)
+
+	version, isFound, err := j.GetVersion(context.Background(), "v2.1.0")
+
+	require.NoError(t, err)
+	assert.True(t, isFound)
+	assert.Equal(t, "10202", version.ID)
+	assert.Equal(t, []string{
+		"maxResults=50&query=v2.1.0&startAt=0",
+		"maxResults=50&query=v2.1.0&startAt=2",
+	}, queries)
+}
+
+func TestJira_GetVersion_NotFound(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/project/10000/version": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"startAt": 0, "maxResults": 50, "total": 1, "isLast": true, "values": [
+				{"id": "10200", "name": "v2.1.0-rc1"}]}`)
+		},
+	})
+	j := newTestJira(t, server)
+
+	_, isFound, err := j.GetVersion(context.Background(), "v2.1.0")
+
+	require.NoError(t, err)
+	assert.False(t, isFound)
+}
+
+func TestJira_GetVersion_FallbackToProjectVersions(t *testing.T) {
+	// test server doesn't serve version search, like older Jira Server
+	server := newTestServer(t, nil)
+	j := newTestJira(t, server)
+
+	version, isFound, err := j.GetVersion(context.Background(), "v1.0.0")
+
+	require.NoError(t, err)
+	assert.True(t, isFound)
+	assert.Equal(t, "10100", version.ID)
+}
+
+func TestJira_GetVersion_SearchError(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/project/10000/version": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		},
+	})
+	j := newTestJira(t, server)
+
+	_, _, err := j.GetVersion(context.Background(), "v1.0.0")
+
+	assert.True(t, errors.Is(err, ErrForbidden), "expected forbidden error, got %v", err)
+}
+
+// versionSearch serves version search with given versions
+func versionSearch(versions ...jira.Version) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var values []jira.Version
+		for _, v := range versions {
+			if strings.Contains(v.Name, r.URL.Query().Get("query")) {
+				values = append(values, v)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(versionPage{Total: len(values), IsLast: true, Values: values})
+	}
+}
+
+func TestJira_MergeVersion(t *testing.T) {
+	var updates []string
+	var deleted string
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/project/10000/version": versionSearch(
+			jira.Version{ID: "10100", Name: "v2.1.0"},
+			jira.Version{ID: "10101", Name: "v2.1.1"},
+		),
+		"/rest/api/2/search": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "fixVersion = 10100", r.URL.Query().Get("jql"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"startAt": 0, "maxResults": 50, "total": 2, "issues": [{"key": "JR-1"}, {"key": "JR-2"}]}`)
+		},
+		"/rest/api/2/issue/": func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			updates = append(updates, r.URL.Path+" "+strings.TrimSpace(string(body)))
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"/rest/api/2/version/": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			deleted = r.URL.String()
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	j := newTestJira(t, server)
+
+	report, err := j.MergeVersion(context.Background(), "v2.1.0", "v2.1.1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JR-1", "JR-2"}, report.Keys(IssueStatusLinked))
+	assert.Equal(t, []string{
+		`/rest/api/2/issue/JR-1 {"update":{"fixVersions":[{"add":{"id":"10101"}},{"remove":{"id":"10100"}}]}}`,
+		`/rest/api/2/issue/JR-2 {"update":{"fixVersions":[{"add":{"id":"10101"}},{"remove":{"id":"10100"}}]}}`,
+	}, updates)
+	assert.Equal(t, "/rest/api/2/version/10100?moveAffectedIssuesTo=10101&moveFixIssuesTo=10101", deleted)
+}
+
+func TestJira_MergeVersion_KeepsSourceOnFailure(t *testing.T) {
+	deleted := false
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/project/10000/version": versionSearch(
+			jira.Version{ID: "10100", Name: "v2.1.0"},
+			jira.Version{ID: "10101", Name: "v2.1.1"},
+		),
+		"/rest/api/2/search": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"startAt": 0, "maxResults": 50, "total": 1, "issues": [{"key": "JR-1"}]}`)
+		},
+		"/rest/api/2/issue/JR-1": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		},
+		"/rest/api/2/version/": func(w http.ResponseWriter, r *http.Request) {
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	j := newTestJira(t, server)
+
+	report, err := j.MergeVersion(context.Background(), "v2.1.0", "v2.1.1")
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"JR-1"}, report.Keys(IssueStatusFailed))
+	assert.False(t, deleted)
+}
+
+func TestJira_RenameVersion(t *testing.T) {
+	var body string
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/project/10000/version": versionSearch(
+			jira.Version{ID: "10100", Name: "v2.1.0"},
+			jira.Version{ID: "10101", Name: "v2.1.1"},
+		),
+		"/rest/api/2/version/10100": func(w http.ResponseWriter, r *http.Request) {
+			b, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			body = string(b)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"id": "10100", "name": "v2.2.0"}`)
+		},
+	})
+	j := newTestJira(t, server)
+
+	version, err := j.RenameVersion(context.Background(), "v2.1.0", "v2.2.0")
+
+	require.NoError(t, err)
+	assert.Equal(t, "v2.2.0", version.Name)
+	assert.JSONEq(t, `{"id": "10100", "name": "v2.2.0"}`, body)
+
+	_, err = j.RenameVersion(context.Background(), "v2.1.0", "v2.1.1")
+	assert.True(t, errors.Is(err, ErrVersionConflict), "expected version conflict, got %v", err)
+
+	_, err = j.RenameVersion(context.Background(), "v3.0.0", "v3.0.1")
+	assert.True(t, errors.Is(err, ErrNotFound), "expected not found, got %v", err)
+}
+
+func TestJira_ReleaseVersion(t *testing.T) {
+	var released jira.Version
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/version/10100": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&released))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(jira.Version{ID: "10100", Name: "v1.0.0", Released: true, ReleaseDate: released.ReleaseDate})
+		},
+	})
+	j := newTestJira(t, server)
+
+	err := j.ReleaseVersion(context.Background())
+
+	require.NoError(t, err)
+	assert.True(t, released.Released)
+	assert.Equal(t, time.Now().Format("2006-01-02"), released.ReleaseDate)
+	assert.True(t, j.Version.Released)
+	assert.Equal(t, "v1.0.0", j.Version.Name)
+	assert.Equal(t, "10100", j.Version.ID)
+}
+
+func TestJira_ReleaseVersion_ThenComment(t *testing.T) {
+	var comment struct {
+		Body string `json:"body"`
+	}
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/version/10100": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"id": "10100", "name": "v1.0.0", "released": true}`)
+		},
+		"/rest/api/2/issue/JR-1": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"key": "JR-1", "fields": {"comment": {"comments": []}}}`)
+		},
+		"/rest/api/2/issue/JR-1/comment": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprint(w, `{"id": "1"}`)
+		},
+	})
+	j := newTestJira(t, server)
+	tmpl, err := ParseCommentTemplate("")
+	require.NoError(t, err)
+
+	err = j.ReleaseVersion(context.Background())
+	require.NoError(t, err)
+	report := Report{Version: j.Version.Name}
+	report.add("JR-1", IssueStatusAlreadyLinked, nil)
+	report = j.CommentLinkedTasks(context.Background(), report, tmpl, map[string]CommentData{"JR-1": {}})
+
+	require.NoError(t, report.Issues[0].CommentErr)
+	assert.Equal(t, "Released in version v1.0.0.\n\n_jira-versioner: v1.0.0_", comment.Body)
+}
+
+func TestJira_LinkedTasksReport(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/rest/api/2/search": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"startAt": 0, "maxResults": 50, "total": 2, "issues": [{"key": "JR-1"}, {"key": "JR-3"}]}`)
+		},
+	})
+	j := newTestJira(t, server)
+
+	report, err := j.LinkedTasksReport(context.Background(), []string{"JR-1", "JR-2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JR-1"}, report.Keys(IssueStatusAlreadyLinked))
+	assert.Len(t, report.Issues, 1)
+}